@@ -0,0 +1,199 @@
+package chunk
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Size of the chunks. Without MaxChars and MaxTokens chunks have at most 1000 characters
+type Options struct {
+	MaxChars  int // maximum number of characters of a chunk
+	MaxTokens int // maximum number of tokens of a chunk, estimated with 4 characters per token, replaces MaxChars
+	Overlap   int // characters, or tokens with MaxTokens, at the end of a chunk repeated at the start of the next chunk of the same section
+}
+
+// Part of the content with its origin in the document
+type Chunk struct {
+	Index    int      `json:"index"`
+	Text     string   `json:"text"` // parts of a paragraph are joined with a space, paragraphs with an empty line
+	Tokens   int      `json:"tokens"`
+	Headings []string `json:"headings"` // path of the headings of the section
+	Pages    []int    `json:"pages"`
+	Regions  []Region `json:"regions"` // area of the content per page, empty for content without boxes
+}
+
+// Area of a page
+type Region struct {
+	Page int `json:"page"`
+	Box  Box `json:"box"`
+}
+
+const (
+	defaultMaxChars = 1000
+	charsPerToken   = 4
+)
+
+type piece struct {
+	paragraph int
+	part      Part
+	length    int
+}
+
+func (o Options) budget() (int, int) {
+	maxChars, overlap := o.MaxChars, o.Overlap
+	if o.MaxTokens > 0 {
+		maxChars, overlap = o.MaxTokens*charsPerToken, o.Overlap*charsPerToken
+	}
+	if maxChars <= 0 {
+		maxChars = defaultMaxChars
+	}
+
+	return maxChars, min(max(overlap, 0), maxChars/2)
+}
+
+// Splits the sections into chunks. A chunk contains whole paragraphs of one section as long as
+// they fit, only paragraphs longer than the maximum size are split between their parts and parts
+// longer than the maximum size between their characters. With an overlap the next chunk of a
+// section starts with the last parts of the previous one
+func Split(sections []Section, options Options) []Chunk {
+	maxChars, overlap := options.budget()
+
+	chunks := []Chunk{}
+	for _, section := range sections {
+		pieces, paragraphLengths := sectionPieces(section, maxChars)
+
+		current := []piece{}
+		fresh := 0 // pieces of the current chunk that are not repeated from the previous one
+		for i, next := range pieces {
+			need := next.length
+			if i == 0 || pieces[i-1].paragraph != next.paragraph {
+				if length := paragraphLengths[next.paragraph]; length <= maxChars {
+					need = length
+				}
+			}
+
+			if fresh != 0 && piecesLength(current)+joinLength(current, next)+need > maxChars {
+				chunks = append(chunks, newChunk(len(chunks), section.Headings, current))
+				current = overlapPieces(current, overlap)
+				fresh = 0
+			}
+
+			// The overlap is reduced if the next piece or paragraph would not fit
+			for len(current) != 0 && piecesLength(current)+joinLength(current, next)+need > maxChars {
+				current = current[1:]
+			}
+
+			current = append(current, next)
+			fresh++
+		}
+
+		if fresh != 0 {
+			chunks = append(chunks, newChunk(len(chunks), section.Headings, current))
+		}
+	}
+
+	return chunks
+}
+
+// Get the pieces of the section and the length of its paragraphs, parts longer than maxChars are split
+func sectionPieces(section Section, maxChars int) ([]piece, []int) {
+	pieces := []piece{}
+	lengths := []int{}
+	for p, paragraph := range section.Paragraphs {
+		length := 0
+		for _, part := range paragraph.Parts {
+			text := strings.TrimSpace(part.Text)
+			for text != "" {
+				runes := []rune(text)
+				end := min(len(runes), maxChars)
+
+				split := part
+				split.Text = string(runes[:end])
+				pieces = append(pieces, piece{paragraph: p, part: split, length: end})
+				if length != 0 {
+					length++
+				}
+				length += end
+
+				text = string(runes[end:])
+			}
+		}
+		lengths = append(lengths, length)
+	}
+
+	return pieces, lengths
+}
+
+// Get the number of characters added between the pieces and the next one
+func joinLength(pieces []piece, next piece) int {
+	if len(pieces) == 0 {
+		return 0
+	}
+	if pieces[len(pieces)-1].paragraph == next.paragraph {
+		return 1
+	}
+
+	return 2
+}
+
+func piecesLength(pieces []piece) int {
+	length := 0
+	for i, piece := range pieces {
+		length += joinLength(pieces[:i], piece) + piece.length
+	}
+
+	return length
+}
+
+// Get the last pieces with at most overlap characters
+func overlapPieces(pieces []piece, overlap int) []piece {
+	start := len(pieces)
+	for start > 0 && piecesLength(pieces[start-1:]) <= overlap {
+		start--
+	}
+
+	return append([]piece{}, pieces[start:]...)
+}
+
+func newChunk(index int, headings []string, pieces []piece) Chunk {
+	chunk := Chunk{Index: index, Headings: append([]string{}, headings...), Pages: []int{}, Regions: []Region{}}
+
+	var builder strings.Builder
+	boxes := map[int]Box{}
+	pages := map[int]bool{}
+	for i, piece := range pieces {
+		switch joinLength(pieces[:i], piece) {
+		case 1:
+			builder.WriteString(" ")
+		case 2:
+			builder.WriteString("\n\n")
+		}
+		builder.WriteString(piece.part.Text)
+
+		pages[piece.part.Page] = true
+		if area := piece.part.area(); area != nil {
+			if box, ok := boxes[piece.part.Page]; ok {
+				boxes[piece.part.Page] = union(box, *area)
+			} else {
+				boxes[piece.part.Page] = *area
+			}
+		}
+	}
+
+	chunk.Text = builder.String()
+	chunk.Tokens = (utf8.RuneCountInString(chunk.Text) + charsPerToken - 1) / charsPerToken
+
+	for page := range pages {
+		chunk.Pages = append(chunk.Pages, page)
+	}
+	sort.Ints(chunk.Pages)
+
+	for _, page := range chunk.Pages {
+		if box, ok := boxes[page]; ok {
+			chunk.Regions = append(chunk.Regions, Region{Page: page, Box: box})
+		}
+	}
+
+	return chunk
+}
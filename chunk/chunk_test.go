@@ -0,0 +1,81 @@
+package chunk_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/chunk"
+	"github.com/stretchr/testify/assert"
+)
+
+func paragraph(page int, text string) chunk.Paragraph {
+	paragraph := chunk.Paragraph{}
+	for _, word := range strings.Fields(text) {
+		paragraph.Parts = append(paragraph.Parts, chunk.Part{Text: word, Page: page})
+	}
+	return paragraph
+}
+
+func texts(chunks []chunk.Chunk) []string {
+	result := []string{}
+	for _, c := range chunks {
+		result = append(result, c.Text)
+	}
+	return result
+}
+
+func TestSplit(t *testing.T) {
+	sections := []chunk.Section{
+		{Headings: []string{"Intro"}, Paragraphs: []chunk.Paragraph{paragraph(1, "aaa bbb"), paragraph(1, "ccc ddd eee"), paragraph(2, "fff")}},
+		{Paragraphs: []chunk.Paragraph{paragraph(2, "one two three four five six")}},
+	}
+
+	t.Run("paragraphs and sections", func(t *testing.T) {
+		chunks := chunk.Split(sections, chunk.Options{MaxChars: 20})
+		assert.Equal(t, []string{"aaa bbb\n\nccc ddd eee", "fff", "one two three four", "five six"}, texts(chunks))
+
+		assert.Equal(t, chunk.Chunk{
+			Index:    1,
+			Text:     "fff",
+			Tokens:   1,
+			Headings: []string{"Intro"},
+			Pages:    []int{2},
+			Regions:  []chunk.Region{},
+		}, chunks[1])
+		assert.Equal(t, []string{}, chunks[2].Headings)
+		assert.Equal(t, 3, chunks[3].Index)
+	})
+
+	t.Run("tokens and overlap", func(t *testing.T) {
+		chunks := chunk.Split(sections, chunk.Options{MaxTokens: 5, Overlap: 1})
+		assert.Equal(t, []string{"aaa bbb\n\nccc ddd eee", "eee\n\nfff", "one two three four", "four five six"}, texts(chunks))
+		assert.Equal(t, []int{1, 2}, chunks[1].Pages)
+
+		// The overlap is dropped if the next paragraph would not fit with it
+		chunks = chunk.Split([]chunk.Section{{Paragraphs: []chunk.Paragraph{paragraph(1, "aaa bbb"), paragraph(1, "ccc ddd eee fff")}}}, chunk.Options{MaxChars: 16, Overlap: 3})
+		assert.Equal(t, []string{"aaa bbb", "ccc ddd eee fff"}, texts(chunks))
+	})
+
+	t.Run("long parts", func(t *testing.T) {
+		chunks := chunk.Split([]chunk.Section{{Paragraphs: []chunk.Paragraph{paragraph(1, "abcdefghijklmnopqrstuvwxy")}}}, chunk.Options{MaxChars: 10})
+		assert.Equal(t, []string{"abcdefghij", "klmnopqrst", "uvwxy"}, texts(chunks))
+
+		assert.Empty(t, chunk.Split([]chunk.Section{{}}, chunk.Options{}))
+	})
+
+	t.Run("regions", func(t *testing.T) {
+		chunks := chunk.Split([]chunk.Section{{Paragraphs: []chunk.Paragraph{{Parts: []chunk.Part{
+			{Text: "left", Page: 1, Box: &chunk.Box{XMin: 10, YMin: 10, XMax: 20, YMax: 20}},
+			{Text: "right", Page: 1, Box: &chunk.Box{XMin: 30, YMin: 12, XMax: 40, YMax: 22}},
+			{Text: "next", Page: 2, Box: &chunk.Box{XMin: 10, YMin: 50, XMax: 20, YMax: 60}},
+			{Text: "unknown", Page: 3},
+		}}}}}, chunk.Options{})
+
+		assert.Len(t, chunks, 1)
+		assert.Equal(t, []int{1, 2, 3}, chunks[0].Pages)
+		assert.Equal(t, []chunk.Region{
+			{Page: 1, Box: chunk.Box{XMin: 10, YMin: 10, XMax: 40, YMax: 22}},
+			{Page: 2, Box: chunk.Box{XMin: 10, YMin: 50, XMax: 20, YMax: 60}},
+		}, chunks[0].Regions)
+	})
+}
@@ -0,0 +1,169 @@
+package chunk
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+)
+
+// Box on a page in the unit of the source, points for pdftotext and pixels for pdftohtml
+type Box struct {
+	XMin float64 `json:"xMin"`
+	YMin float64 `json:"yMin"`
+	XMax float64 `json:"xMax"`
+	YMax float64 `json:"yMax"`
+}
+
+// Content below a heading, chunks never span two sections
+type Section struct {
+	Headings   []string // path of the headings, empty for content without a heading
+	Paragraphs []Paragraph
+}
+
+// Paragraph of a section, chunks are split between paragraphs if possible
+type Paragraph struct {
+	Parts []Part
+}
+
+// Smallest unit of a paragraph that is not split, e.g. a word or a line
+type Part struct {
+	Text string
+	Page int
+	Box  *Box // box of the part, nil if the position is not known
+
+	// Box of the element containing the part if the part has no box of its own, e.g. the paragraph
+	// of a word of FromXML
+	ElementBox *Box
+}
+
+// Get the box of the part or else of its element, nil if the position is not known
+func (p Part) area() *Box {
+	if p.Box != nil {
+		return p.Box
+	}
+
+	return p.ElementBox
+}
+
+var paragraphSeparatorRegexp = regexp.MustCompile(`\n[ \t]*\n`)
+
+// Get the section of the pdftotext output. The pages are separated by form feeds and numbered
+// starting at firstPage, paragraphs are separated by empty lines. The parts have no boxes
+func FromText(content string, firstPage int) []Section {
+	section := Section{Paragraphs: []Paragraph{}}
+
+	pages := strings.Split(content, "\f")
+	if len(pages) > 1 && strings.TrimSpace(pages[len(pages)-1]) == "" {
+		pages = pages[:len(pages)-1]
+	}
+
+	for i, page := range pages {
+		for _, text := range paragraphSeparatorRegexp.Split(page, -1) {
+			paragraph := Paragraph{}
+			for _, word := range strings.Fields(text) {
+				paragraph.Parts = append(paragraph.Parts, Part{Text: word, Page: firstPage + i})
+			}
+			if len(paragraph.Parts) != 0 {
+				section.Paragraphs = append(section.Paragraphs, paragraph)
+			}
+		}
+	}
+
+	return []Section{section}
+}
+
+// Get the section of the pdftotext -bbox output with a part for every word. Words are in the same
+// line if their vertical center is inside the line, a paragraph ends at the end of a page or if
+// the gap to the next line is larger than half of the line height
+func FromBbox(pages []pdf2text.BboxPage) []Section {
+	section := Section{Paragraphs: []Paragraph{}}
+
+	for _, page := range pages {
+		paragraph := Paragraph{}
+		line := Box{} // box of the current line
+		for _, word := range page.Words {
+			if strings.TrimSpace(word.Text) == "" {
+				continue
+			}
+
+			box := Box{XMin: word.XMin, YMin: word.YMin, XMax: word.XMax, YMax: word.YMax}
+			center := (box.YMin + box.YMax) / 2
+			switch {
+			case len(paragraph.Parts) == 0:
+				line = box
+			case center < line.YMin || center > line.YMax:
+				if box.YMin-line.YMax > (line.YMax-line.YMin)/2 {
+					section.Paragraphs = append(section.Paragraphs, paragraph)
+					paragraph = Paragraph{}
+				}
+				line = box
+			default:
+				line = union(line, box)
+			}
+
+			paragraph.Parts = append(paragraph.Parts, Part{Text: strings.TrimSpace(word.Text), Page: page.Number, Box: &box})
+		}
+
+		if len(paragraph.Parts) != 0 {
+			section.Paragraphs = append(section.Paragraphs, paragraph)
+		}
+	}
+
+	return []Section{section}
+}
+
+// Get the sections of the structure of the document, see pdf2html.PdfXmlData.Structure. Every
+// paragraph, list item and caption is a paragraph with a part for every word. The positions of
+// the words are not known, the parts have the ElementBox of their element but no Box
+func FromXML(data pdf2html.PdfXmlData) []Section {
+	document := data.Structure()
+
+	sections := []Section{}
+	if len(document.Elements) != 0 {
+		sections = append(sections, newSection([]string{}, document.Elements))
+	}
+
+	var add func(section *pdf2html.StructuredSection, headings []string)
+	add = func(section *pdf2html.StructuredSection, headings []string) {
+		headings = append(append([]string{}, headings...), section.Heading.Text)
+		if len(section.Elements) != 0 {
+			sections = append(sections, newSection(headings, section.Elements))
+		}
+		for _, subsection := range section.Sections {
+			add(subsection, headings)
+		}
+	}
+	for _, section := range document.Sections {
+		add(section, []string{})
+	}
+
+	return sections
+}
+
+func newSection(headings []string, elements []pdf2html.StructuredElement) Section {
+	section := Section{Headings: headings, Paragraphs: []Paragraph{}}
+	for _, element := range elements {
+		box := Box{
+			XMin: float64(element.Rect.Left),
+			YMin: float64(element.Rect.Top),
+			XMax: float64(element.Rect.Right),
+			YMax: float64(element.Rect.Bottom),
+		}
+
+		paragraph := Paragraph{}
+		for _, word := range strings.Fields(element.Text) {
+			paragraph.Parts = append(paragraph.Parts, Part{Text: word, Page: element.Page, ElementBox: &box})
+		}
+		if len(paragraph.Parts) != 0 {
+			section.Paragraphs = append(section.Paragraphs, paragraph)
+		}
+	}
+
+	return section
+}
+
+func union(a, b Box) Box {
+	return Box{XMin: min(a.XMin, b.XMin), YMin: min(a.YMin, b.YMin), XMax: max(a.XMax, b.XMax), YMax: max(a.YMax, b.YMax)}
+}
@@ -0,0 +1,108 @@
+package chunk_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/chunk"
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/stretchr/testify/assert"
+)
+
+func pointerHelperFn[T any](x T) *T {
+	return &x
+}
+
+func TestFromText(t *testing.T) {
+	assert.Equal(t, []chunk.Section{{Paragraphs: []chunk.Paragraph{
+		paragraph(3, "Hello world"),
+		paragraph(3, "Second paragraph over two lines"),
+		paragraph(4, "Page two"),
+	}}}, chunk.FromText("Hello world\n  \nSecond paragraph\nover two lines\n\fPage two\n\f", 3))
+}
+
+func TestFromBbox(t *testing.T) {
+	word := func(xMin, yMin, xMax, yMax float64, text string) pdf2text.BboxWord {
+		return pdf2text.BboxWord{XMin: xMin, YMin: yMin, XMax: xMax, YMax: yMax, Text: text}
+	}
+	part := func(page int, text string, xMin, yMin, xMax, yMax float64) chunk.Part {
+		return chunk.Part{Text: text, Page: page, Box: &chunk.Box{XMin: xMin, YMin: yMin, XMax: xMax, YMax: yMax}}
+	}
+
+	sections := chunk.FromBbox([]pdf2text.BboxPage{
+		{Number: 1, Words: []pdf2text.BboxWord{
+			word(10, 10, 30, 20, "Line"), word(35, 11, 50, 21, "one"),
+			word(10, 22, 30, 32, "line"), word(35, 22, 50, 32, "two"), word(55, 22, 60, 32, " "),
+			word(10, 50, 30, 60, "New"),
+		}},
+		{Number: 2, Words: []pdf2text.BboxWord{word(10, 10, 30, 20, "Next")}},
+	})
+
+	assert.Equal(t, []chunk.Section{{Paragraphs: []chunk.Paragraph{
+		{Parts: []chunk.Part{part(1, "Line", 10, 10, 30, 20), part(1, "one", 35, 11, 50, 21), part(1, "line", 10, 22, 30, 32), part(1, "two", 35, 22, 50, 32)}},
+		{Parts: []chunk.Part{part(1, "New", 10, 50, 30, 60)}},
+		{Parts: []chunk.Part{part(2, "Next", 10, 10, 30, 20)}},
+	}}}, sections)
+
+	chunks := chunk.Split(sections, chunk.Options{})
+	assert.Equal(t, "Line one line two\n\nNew\n\nNext", chunks[0].Text)
+	assert.Equal(t, []chunk.Region{
+		{Page: 1, Box: chunk.Box{XMin: 10, YMin: 10, XMax: 50, YMax: 60}},
+		{Page: 2, Box: chunk.Box{XMin: 10, YMin: 10, XMax: 30, YMax: 20}},
+	}, chunks[0].Regions)
+}
+
+func TestFromXML(t *testing.T) {
+	text := func(top, height, font int, content string) pdf2html.PdfXmlText {
+		return pdf2html.PdfXmlText{
+			Top:    pointerHelperFn(top),
+			Left:   pointerHelperFn(50),
+			Width:  pointerHelperFn(300),
+			Height: pointerHelperFn(height),
+			Font:   pointerHelperFn(font),
+			Text:   pointerHelperFn(content),
+		}
+	}
+	fontSpec := func(id, size int) pdf2html.PdfXmlFontSpec {
+		return pdf2html.PdfXmlFontSpec{ID: pointerHelperFn(id), Size: pointerHelperFn(size), Family: pointerHelperFn("Arial")}
+	}
+
+	data := pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{{
+		PageNumber: pointerHelperFn(1),
+		FontSpecs:  []pdf2html.PdfXmlFontSpec{fontSpec(0, 12), fontSpec(1, 18), fontSpec(2, 14)},
+		Texts: []pdf2html.PdfXmlText{
+			text(20, 12, 0, "Preface text"),
+			text(50, 20, 1, "Guide"),
+			text(90, 16, 2, "Setup"),
+			text(120, 12, 0, "Install the tool."),
+			text(134, 12, 0, "Run it."),
+		},
+	}}}
+
+	box := &chunk.Box{XMin: 50, YMin: 120, XMax: 350, YMax: 146}
+	sections := chunk.FromXML(data)
+	assert.Equal(t, []chunk.Section{
+		{Headings: []string{}, Paragraphs: []chunk.Paragraph{{Parts: []chunk.Part{
+			{Text: "Preface", Page: 1, ElementBox: &chunk.Box{XMin: 50, YMin: 20, XMax: 350, YMax: 32}},
+			{Text: "text", Page: 1, ElementBox: &chunk.Box{XMin: 50, YMin: 20, XMax: 350, YMax: 32}},
+		}}}},
+		{Headings: []string{"Guide", "Setup"}, Paragraphs: []chunk.Paragraph{{Parts: []chunk.Part{
+			{Text: "Install", Page: 1, ElementBox: box},
+			{Text: "the", Page: 1, ElementBox: box},
+			{Text: "tool.", Page: 1, ElementBox: box},
+			{Text: "Run", Page: 1, ElementBox: box},
+			{Text: "it.", Page: 1, ElementBox: box},
+		}}}},
+	}, sections)
+
+	chunks := chunk.Split(sections, chunk.Options{})
+	assert.Len(t, chunks, 2)
+	assert.Equal(t, chunk.Chunk{
+		Index:    1,
+		Text:     "Install the tool. Run it.",
+		Tokens:   7,
+		Headings: []string{"Guide", "Setup"},
+		Pages:    []int{1},
+		Regions:  []chunk.Region{{Page: 1, Box: *box}},
+	}, chunks[1])
+}
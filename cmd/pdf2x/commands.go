@@ -0,0 +1,413 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-pdf2X/pdf2x"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+)
+
+// Client interface for pdftohtml, implemented by pdf2html.Client
+type htmlClient interface {
+	Get(filePath, outputPathPrefix string, options pdf2html.Options) (*pdf2html.Output, error)
+	GetXML(filePath string, options pdf2html.Options) (*pdf2html.PdfXmlData, error)
+	GetHTML(filePath string, options pdf2html.Options) (*string, error)
+}
+
+// Factories for the poppler clients
+type clients struct {
+	text func() (pdf2x.TextClient, error)
+	html func() (htmlClient, error)
+	info func() (pdf2x.InfoClient, error)
+}
+
+var defaultClients = clients{
+	text: func() (pdf2x.TextClient, error) {
+		client, err := pdf2text.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	},
+	html: func() (htmlClient, error) {
+		client, err := pdf2html.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	},
+	info: func() (pdf2x.InfoClient, error) {
+		client, err := pdfinfo.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	},
+}
+
+// Clients that are created on first use and shared between the parallel conversions
+type clientSet struct {
+	factory clients
+
+	mu   sync.Mutex
+	text pdf2x.TextClient
+	html htmlClient
+	info pdf2x.InfoClient
+}
+
+func (c *clientSet) textClient() (pdf2x.TextClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.text == nil {
+		client, err := c.factory.text()
+		if err != nil {
+			return nil, err
+		}
+		c.text = client
+	}
+
+	return c.text, nil
+}
+
+func (c *clientSet) htmlClient() (htmlClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.html == nil {
+		client, err := c.factory.html()
+		if err != nil {
+			return nil, err
+		}
+		c.html = client
+	}
+
+	return c.html, nil
+}
+
+func (c *clientSet) infoClient() (pdf2x.InfoClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.info == nil {
+		client, err := c.factory.info()
+		if err != nil {
+			return nil, err
+		}
+		c.info = client
+	}
+
+	return c.info, nil
+}
+
+type converter func(c *clientSet, path string) (*result, error)
+
+type command struct {
+	name        string
+	description string
+	bind        func(fs *flag.FlagSet) converter // registers the flags of the command
+}
+
+var commands = []command{
+	{name: "text", description: "extract the text (pdftotext)", bind: bindText},
+	{name: "words", description: "extract the words with bounding boxes (pdftotext -bbox)", bind: bindWords},
+	{name: "html", description: "convert to HTML (pdftohtml)", bind: bindHTML},
+	{name: "xml", description: "convert to XML (pdftohtml -xml)", bind: bindXML},
+	{name: "json", description: "convert to the parsed XML data as JSON", bind: bindJSON},
+	{name: "markdown", description: "convert to Markdown with inferred headings and lists", bind: bindMarkdown},
+	{name: "tables", description: "extract a table of a page", bind: bindTables},
+	{name: "info", description: "print the document information (pdfinfo)", bind: bindInfo},
+	{name: "outline", description: "print the outline of the document", bind: bindOutline},
+}
+
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+
+	return nil
+}
+
+func bindText(fs *flag.FlagSet) converter {
+	options := pdf2text.Options{}
+	bindTextOptions(fs, &options)
+
+	return func(c *clientSet, path string) (*result, error) {
+		client, err := c.textClient()
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := client.Get(path, options)
+		if err != nil {
+			return nil, err
+		}
+
+		text := ""
+		if out != nil {
+			text = *out
+		}
+
+		return &result{Text: text, Value: text}, nil
+	}
+}
+
+func bindWords(fs *flag.FlagSet) converter {
+	options := pdf2text.Options{}
+	bindTextOptions(fs, &options)
+
+	return func(c *clientSet, path string) (*result, error) {
+		client, err := c.textClient()
+		if err != nil {
+			return nil, err
+		}
+
+		pages, err := client.GetBbox(path, options)
+		if err != nil {
+			return nil, err
+		}
+
+		var builder strings.Builder
+		for _, page := range pages {
+			for _, word := range page.Words {
+				fmt.Fprintf(&builder, "%d\t%.2f\t%.2f\t%.2f\t%.2f\t%s\n", page.Number, word.XMin, word.YMin, word.XMax, word.YMax, word.Text)
+			}
+		}
+
+		return &result{Text: builder.String(), Value: pages}, nil
+	}
+}
+
+func bindHTML(fs *flag.FlagSet) converter {
+	options := pdf2html.Options{}
+	bindHTMLOptions(fs, &options)
+
+	return func(c *clientSet, path string) (*result, error) {
+		client, err := c.htmlClient()
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := client.GetHTML(path, options)
+		if err != nil {
+			return nil, err
+		}
+
+		return &result{Text: *out, Value: *out}, nil
+	}
+}
+
+func bindXML(fs *flag.FlagSet) converter {
+	options := pdf2html.Options{}
+	bindHTMLOptions(fs, &options)
+
+	return func(c *clientSet, path string) (*result, error) {
+		client, err := c.htmlClient()
+		if err != nil {
+			return nil, err
+		}
+
+		dir, err := os.MkdirTemp("", "pdf2x-xml-*")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+
+		xmlOptions := options
+		xmlOptions.Xml = true
+		output, err := client.Get(path, filepath.Join(dir, "output"), xmlOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := os.ReadFile(output.XmlFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return &result{Text: string(content), Value: string(content)}, nil
+	}
+}
+
+func bindJSON(fs *flag.FlagSet) converter {
+	options := pdf2html.Options{}
+	bindHTMLOptions(fs, &options)
+
+	return func(c *clientSet, path string) (*result, error) {
+		data, err := getXML(c, path, options)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return &result{Text: string(content) + "\n", Value: data}, nil
+	}
+}
+
+func bindTables(fs *flag.FlagSet) converter {
+	options := pdf2html.Options{}
+	bindHTMLOptions(fs, &options)
+
+	page := fs.Int("page", 1, "page number of the table")
+	from := fs.Int("from", 0, "top position where the table starts")
+	to := fs.Int("to", int(^uint(0)>>1), "top position where the table ends")
+	columns := []int{}
+	fs.Var(intListFlag{&columns}, "columns", "comma separated left positions of the columns, e.g. 100,200,400")
+	columnVariance := fs.Int("column-variance", 10, "allowed variance around the column positions")
+	heightVariance := fs.Int("height-variance", 5, "allowed height variance for texts in the same line")
+
+	return func(c *clientSet, path string) (*result, error) {
+		if len(columns) == 0 {
+			return nil, fmt.Errorf("the -columns flag is required")
+		}
+
+		data, err := getXML(c, path, options)
+		if err != nil {
+			return nil, err
+		}
+
+		xmlPage, err := findPage(data, *page)
+		if err != nil {
+			return nil, err
+		}
+
+		table := xmlPage.ExtractTableContent(pdf2html.PdfXmlTableOption{
+			From:                  *from,
+			To:                    *to,
+			Columns:               len(columns),
+			GetColumnFunc:         pdf2html.GetColumnCalculationWithVariance(columns, *columnVariance),
+			AllowedHeightVariance: *heightVariance,
+		})
+
+		var builder strings.Builder
+		err = pdf2html.WriteTableTSV(&builder, table, pdf2html.TableExportOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		return &result{Text: builder.String(), Value: table}, nil
+	}
+}
+
+func bindInfo(fs *flag.FlagSet) converter {
+	options := pdfinfo.Options{}
+	bindInfoOptions(fs, &options)
+
+	return func(c *clientSet, path string) (*result, error) {
+		client, err := c.infoClient()
+		if err != nil {
+			return nil, err
+		}
+
+		info, err := client.Get(path, options)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := []string{}
+		for key := range info.Fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var builder strings.Builder
+		for _, key := range keys {
+			fmt.Fprintf(&builder, "%s: %s\n", key, info.Fields[key])
+		}
+
+		return &result{Text: builder.String(), Value: info}, nil
+	}
+}
+
+func bindOutline(fs *flag.FlagSet) converter {
+	options := pdf2html.Options{}
+	bindHTMLOptions(fs, &options)
+
+	return func(c *clientSet, path string) (*result, error) {
+		data, err := getXML(c, path, options)
+		if err != nil {
+			return nil, err
+		}
+
+		outlines := data.Outlines
+		if outlines == nil {
+			outlines = []pdf2html.PdfXmlOutline{}
+		}
+
+		var builder strings.Builder
+		writeOutlines(&builder, outlines, 0)
+
+		return &result{Text: builder.String(), Value: outlines}, nil
+	}
+}
+
+func bindMarkdown(fs *flag.FlagSet) converter {
+	options := pdf2html.Options{}
+	bindHTMLOptions(fs, &options)
+	pageBreaks := fs.Bool("pagebreaks", false, "separate the pages with a thematic break")
+
+	return func(c *clientSet, path string) (*result, error) {
+		data, err := getXML(c, path, options)
+		if err != nil {
+			return nil, err
+		}
+
+		out := data.ToMarkdown(pdf2html.MarkdownOptions{PageBreaks: *pageBreaks})
+
+		return &result{Text: out, Value: out}, nil
+	}
+}
+
+func getXML(c *clientSet, path string, options pdf2html.Options) (*pdf2html.PdfXmlData, error) {
+	client, err := c.htmlClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetXML(path, options)
+}
+
+func findPage(data *pdf2html.PdfXmlData, number int) (*pdf2html.PdfXmlPage, error) {
+	for i, page := range data.Pages {
+		if page.PageNumber != nil && *page.PageNumber == number {
+			return &data.Pages[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot find page %d", number)
+}
+
+func writeOutlines(builder *strings.Builder, outlines []pdf2html.PdfXmlOutline, depth int) {
+	for _, outline := range outlines {
+		for _, item := range outline.Items {
+			title := ""
+			if item.Content != nil {
+				title = *item.Content
+			}
+
+			builder.WriteString(strings.Repeat("  ", depth))
+			builder.WriteString(title)
+			if item.Page != nil {
+				fmt.Fprintf(builder, " (%d)", *item.Page)
+			}
+			builder.WriteString("\n")
+		}
+
+		writeOutlines(builder, outline.Outlines, depth+1)
+	}
+}
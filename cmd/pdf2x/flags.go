@@ -0,0 +1,165 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+)
+
+// Flag for optional int options, the pointer stays nil if the flag is not set
+type intFlag struct {
+	value **int
+}
+
+func (f intFlag) String() string {
+	if f.value == nil || *f.value == nil {
+		return ""
+	}
+	return strconv.Itoa(**f.value)
+}
+
+func (f intFlag) Set(s string) error {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*f.value = &v
+	return nil
+}
+
+// Flag for optional float options, the pointer stays nil if the flag is not set
+type floatFlag struct {
+	value **float32
+}
+
+func (f floatFlag) String() string {
+	if f.value == nil || *f.value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(float64(**f.value), 'f', -1, 32)
+}
+
+func (f floatFlag) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return err
+	}
+	value := float32(v)
+	*f.value = &value
+	return nil
+}
+
+// Flag for optional string options, the pointer stays nil if the flag is not set
+type stringFlag struct {
+	value **string
+}
+
+func (f stringFlag) String() string {
+	if f.value == nil || *f.value == nil {
+		return ""
+	}
+	return **f.value
+}
+
+func (f stringFlag) Set(s string) error {
+	*f.value = &s
+	return nil
+}
+
+// Flag for a comma separated list of ints
+type intListFlag struct {
+	value *[]int
+}
+
+func (f intListFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+
+	values := []string{}
+	for _, v := range *f.value {
+		values = append(values, strconv.Itoa(v))
+	}
+	return strings.Join(values, ",")
+}
+
+func (f intListFlag) Set(s string) error {
+	values := []int{}
+	for _, part := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf("invalid list entry %q", part)
+		}
+		values = append(values, v)
+	}
+	*f.value = values
+	return nil
+}
+
+// Registers the flags of pdftotext, the names are the same as for the CLI
+func bindTextOptions(fs *flag.FlagSet, options *pdf2text.Options) {
+	fs.Var(intFlag{&options.FirstPage}, "f", "first page to convert")
+	fs.Var(intFlag{&options.LastPage}, "l", "last page to convert")
+	fs.Var(intFlag{&options.Resolution}, "r", "resolution, in DPI (default is 72)")
+	fs.Var(intFlag{&options.X}, "x", "x-coordinate of the crop area top left corner")
+	fs.Var(intFlag{&options.Y}, "y", "y-coordinate of the crop area top left corner")
+	fs.Var(intFlag{&options.Width}, "W", "width of crop area in pixels (default is 0)")
+	fs.Var(intFlag{&options.Height}, "H", "height of crop area in pixels (default is 0)")
+	fs.BoolVar(&options.Layout, "layout", false, "maintain original physical layout")
+	fs.Var(stringFlag{&options.Fixed}, "fixed", "assume fixed-pitch (or tabular) text")
+	fs.BoolVar(&options.Raw, "raw", false, "keep strings in content stream order")
+	fs.BoolVar(&options.NoDiag, "nodiag", false, "discard diagonal text")
+	fs.BoolVar(&options.HtmlMeta, "htmlmeta", false, "generate a simple HTML file, including the meta information")
+	fs.BoolVar(&options.Tsv, "tsv", false, "generate a simple TSV file, including the meta information for bounding boxes")
+	fs.Var(stringFlag{&options.Enc}, "enc", "output text encoding name")
+	fs.Var(stringFlag{&options.Eol}, "eol", "output end-of-line convention (unix, dos, or mac)")
+	fs.BoolVar(&options.Nopgbrk, "nopgbrk", false, "don't insert page breaks between pages")
+	fs.BoolVar(&options.Bbox, "bbox", false, "output bounding box for each word and page size to html")
+	fs.BoolVar(&options.BboxLayout, "bbox-layout", false, "like -bbox but with extra layout bounding box data")
+	fs.BoolVar(&options.CropBox, "cropbox", false, "use the crop box rather than media box")
+	fs.Var(floatFlag{&options.ColSpacing}, "colspacing", "how much spacing we allow after a word before considering adjacent text to be a new column, as a fraction of the font size")
+	fs.Var(stringFlag{&options.OwnerPassword}, "opw", "owner password (for encrypted files)")
+	fs.Var(stringFlag{&options.UserPassword}, "upw", "user password (for encrypted files)")
+}
+
+// Registers the flags of pdftohtml, the names are the same as for the CLI
+func bindHTMLOptions(fs *flag.FlagSet, options *pdf2html.Options) {
+	fs.Var(intFlag{&options.FirstPage}, "f", "first page to convert")
+	fs.Var(intFlag{&options.LastPage}, "l", "last page to convert")
+	fs.BoolVar(&options.Quite, "q", false, "don't print any messages or errors")
+	fs.BoolVar(&options.ExchangeLinks, "p", false, "exchange .pdf links by .html")
+	fs.BoolVar(&options.ComplexDoc, "c", false, "generate complex document")
+	fs.BoolVar(&options.SingleDoc, "s", false, "generate single document that includes all pages")
+	fs.BoolVar(&options.IgnoreImages, "i", false, "ignore images")
+	fs.BoolVar(&options.NoFrames, "noframes", false, "generate no frames")
+	fs.BoolVar(&options.Stdout, "stdout", false, "use standard output")
+	fs.Var(floatFlag{&options.Zoom}, "zoom", "zoom the pdf document (default 1.5)")
+	fs.BoolVar(&options.Xml, "xml", false, "output for XML post-processing")
+	fs.BoolVar(&options.NoRoundCoord, "noroundcoord", false, "do not round coordinates (with XML output only)")
+	fs.BoolVar(&options.Hidden, "hidden", false, "output hidden text")
+	fs.BoolVar(&options.NoMerge, "nomerge", false, "do not merge paragraphs")
+	fs.Var(stringFlag{&options.Enc}, "enc", "output text encoding name")
+	fs.Var(stringFlag{&options.Fmt}, "fmt", "image file format for Splash output (png or jpg)")
+	fs.Var(stringFlag{&options.OwnerPassword}, "opw", "owner password (for encrypted files)")
+	fs.Var(stringFlag{&options.UserPassword}, "upw", "user password (for encrypted files)")
+	fs.BoolVar(&options.NoDrm, "nodrm", false, "override document DRM settings")
+	fs.Var(intFlag{&options.Wbt}, "wbt", "word break threshold (default 10 percent)")
+	fs.BoolVar(&options.FontFullName, "fontfullname", false, "outputs font full name")
+}
+
+// Registers the flags of pdfinfo, the names are the same as for the CLI
+func bindInfoOptions(fs *flag.FlagSet, options *pdfinfo.Options) {
+	fs.Var(intFlag{&options.FirstPage}, "f", "first page to convert")
+	fs.Var(intFlag{&options.LastPage}, "l", "last page to convert")
+	fs.BoolVar(&options.Box, "box", false, "print the page bounding boxes")
+	fs.BoolVar(&options.IsoDates, "isodates", false, "print the dates in ISO-8601 format")
+	fs.BoolVar(&options.RawDates, "rawdates", false, "print the undecoded date strings directly from the PDF file")
+	fs.Var(stringFlag{&options.Enc}, "enc", "output text encoding name")
+	fs.Var(stringFlag{&options.OwnerPassword}, "opw", "owner password (for encrypted files)")
+	fs.Var(stringFlag{&options.UserPassword}, "upw", "user password (for encrypted files)")
+}
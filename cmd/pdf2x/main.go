@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, defaultClients))
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: pdf2x <command> [flags] [files or globs...]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Without files or with \"-\" the PDF is read from stdin.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-8s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintf(w, "  %-8s %s\n", "serve", "run the HTTP conversion server")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Use \"pdf2x <command> -h\" for the flags of a command.")
+}
+
+// Runs the CLI and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, factory clients) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	if args[0] == "serve" {
+		return runServe(args[1:], stderr, factory)
+	}
+
+	cmd := findCommand(args[0])
+	if cmd == nil {
+		fmt.Fprintf(stderr, "pdf2x: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	fs := flag.NewFlagSet(fmt.Sprintf("pdf2x %s", cmd.name), flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	convert := cmd.bind(fs)
+
+	options := batchOptions{}
+	fs.IntVar(&options.Jobs, "j", 1, "number of files converted in parallel")
+	fs.BoolVar(&options.JSON, "json", false, "write JSON output")
+
+	err := fs.Parse(args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	inputs, err := expandInputs(fs.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf2x: %s\n", err)
+		return 2
+	}
+
+	set := &clientSet{factory: factory}
+	err = runBatch(inputs, options, stdin, stdout, stderr, func(path string) (*result, error) {
+		return convert(set, path)
+	})
+	if err != nil {
+		if len(inputs) > 1 {
+			fmt.Fprintf(stderr, "pdf2x: %s\n", err)
+		}
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-pdf2X/pdf2x"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTextClient struct {
+	mu    sync.Mutex
+	calls []pdf2text.Options
+}
+
+func (c *testTextClient) Get(filePath string, options pdf2text.Options) (*string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, options)
+
+	if strings.Contains(filePath, "broken") {
+		return nil, fmt.Errorf("cannot convert")
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	text := fmt.Sprintf("text of %s\n", string(content))
+	return &text, nil
+}
+
+func (c *testTextClient) GetBbox(filePath string, options pdf2text.Options) ([]pdf2text.BboxPage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, options)
+
+	return []pdf2text.BboxPage{
+		{
+			Number: 1,
+			Words: []pdf2text.BboxWord{
+				{XMin: 1, YMin: 2, XMax: 3, YMax: 4, Text: "Test"},
+			},
+		},
+	}, nil
+}
+
+type testHTMLClient struct {
+	calls []pdf2html.Options
+}
+
+func (c *testHTMLClient) Get(filePath, outputPathPrefix string, options pdf2html.Options) (*pdf2html.Output, error) {
+	c.calls = append(c.calls, options)
+
+	xmlFile := fmt.Sprintf("%s.xml", outputPathPrefix)
+	err := os.WriteFile(xmlFile, []byte("<pdf2xml></pdf2xml>"), 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pdf2html.Output{XmlFile: xmlFile, HtmlFile: fmt.Sprintf("%s.html", outputPathPrefix)}, nil
+}
+
+func (c *testHTMLClient) GetXML(filePath string, options pdf2html.Options) (*pdf2html.PdfXmlData, error) {
+	c.calls = append(c.calls, options)
+
+	return &pdf2html.PdfXmlData{
+		Producer: pointerHelperFn("poppler"),
+		Pages: []pdf2html.PdfXmlPage{
+			{
+				PageNumber: pointerHelperFn(1),
+				Texts: []pdf2html.PdfXmlText{
+					{Top: pointerHelperFn(100), Left: pointerHelperFn(100), Text: pointerHelperFn("A1")},
+					{Top: pointerHelperFn(100), Left: pointerHelperFn(200), Text: pointerHelperFn(" B1"), BoldText: pointerHelperFn("Bold")},
+					{Top: pointerHelperFn(120), Left: pointerHelperFn(100), Text: pointerHelperFn(" ignored"), BoldText: pointerHelperFn("A"), Runs: []pdf2html.PdfXmlRun{{Text: "A", Bold: true}, {Text: "2", Italic: true}, {Text: " "}}},
+				},
+			},
+		},
+		Outlines: []pdf2html.PdfXmlOutline{
+			{
+				Items: []pdf2html.PdfXmlOutlineItem{
+					{Page: pointerHelperFn(1), Content: pointerHelperFn("Chapter 1")},
+				},
+				Outlines: []pdf2html.PdfXmlOutline{
+					{
+						Items: []pdf2html.PdfXmlOutlineItem{
+							{Page: pointerHelperFn(1), Content: pointerHelperFn("Section 1.1")},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *testHTMLClient) GetHTML(filePath string, options pdf2html.Options) (*string, error) {
+	c.calls = append(c.calls, options)
+	return pointerHelperFn("<html></html>"), nil
+}
+
+type testInfoClient struct {
+	calls []pdfinfo.Options
+}
+
+func (c *testInfoClient) Get(filePath string, options pdfinfo.Options) (*pdfinfo.Info, error) {
+	c.calls = append(c.calls, options)
+	return &pdfinfo.Info{
+		Pages:  pointerHelperFn(1),
+		Fields: map[string]string{"Pages": "1", "Producer": "poppler"},
+	}, nil
+}
+
+func pointerHelperFn[T any](x T) *T {
+	return &x
+}
+
+func setupClients() (clients, *testTextClient, *testHTMLClient, *testInfoClient) {
+	textClient := &testTextClient{}
+	xmlClient := &testHTMLClient{}
+	infoClient := &testInfoClient{}
+
+	return clients{
+		text: func() (pdf2x.TextClient, error) { return textClient, nil },
+		html: func() (htmlClient, error) { return xmlClient, nil },
+		info: func() (pdf2x.InfoClient, error) { return infoClient, nil },
+	}, textClient, xmlClient, infoClient
+}
+
+func writeTestFiles(t *testing.T, names ...string) string {
+	dir := t.TempDir()
+	for _, name := range names {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o600)
+		assert.Nil(t, err)
+	}
+
+	return dir
+}
+
+func runTest(args []string, stdin string, factory clients) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr, factory)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRunUsage(t *testing.T) {
+	factory, _, _, _ := setupClients()
+
+	code, _, stderr := runTest([]string{}, "", factory)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "Usage: pdf2x <command>")
+	assert.Contains(t, stderr, "outline")
+
+	code, _, _ = runTest([]string{"help"}, "", factory)
+	assert.Equal(t, 0, code)
+
+	code, _, stderr = runTest([]string{"unknown"}, "", factory)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "unknown command \"unknown\"")
+
+	code, _, _ = runTest([]string{"text", "-invalid"}, "", factory)
+	assert.Equal(t, 2, code)
+
+	code, _, _ = runTest([]string{"text", "-h"}, "", factory)
+	assert.Equal(t, 0, code)
+}
+
+func TestRunText(t *testing.T) {
+	t.Run("Single file with flags", func(t *testing.T) {
+		factory, textClient, _, _ := setupClients()
+		dir := writeTestFiles(t, "a.pdf")
+
+		code, stdout, _ := runTest([]string{"text", "-f", "2", "-layout", "-upw", "secret", filepath.Join(dir, "a.pdf")}, "", factory)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "text of a.pdf\n", stdout)
+		assert.Equal(t, pdf2text.Options{
+			FirstPage:    pointerHelperFn(2),
+			Layout:       true,
+			UserPassword: pointerHelperFn("secret"),
+		}, textClient.calls[0])
+	})
+
+	t.Run("Stdin", func(t *testing.T) {
+		factory, _, _, _ := setupClients()
+
+		code, stdout, _ := runTest([]string{"text"}, "stdin.pdf", factory)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "text of stdin.pdf\n", stdout)
+
+		code, stdout, _ = runTest([]string{"text", "-json", "-"}, "stdin.pdf", factory)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "\"text of stdin.pdf\\n\"\n", stdout)
+	})
+
+	t.Run("Batch glob in parallel", func(t *testing.T) {
+		factory, textClient, _, _ := setupClients()
+		dir := writeTestFiles(t, "a.pdf", "b.pdf", "c.pdf", "d.txt")
+
+		code, stdout, _ := runTest([]string{"text", "-j", "3", filepath.Join(dir, "*.pdf")}, "", factory)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, fmt.Sprintf("==> %[1]s/a.pdf <==\ntext of a.pdf\n==> %[1]s/b.pdf <==\ntext of b.pdf\n==> %[1]s/c.pdf <==\ntext of c.pdf\n", dir), stdout)
+		assert.Equal(t, 3, len(textClient.calls))
+	})
+
+	t.Run("Batch JSON with errors", func(t *testing.T) {
+		factory, _, _, _ := setupClients()
+		dir := writeTestFiles(t, "a.pdf", "broken.pdf")
+
+		code, stdout, stderr := runTest([]string{"text", "-json", "-j", "2", filepath.Join(dir, "a.pdf"), filepath.Join(dir, "broken.pdf")}, "", factory)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "broken.pdf: cannot convert")
+		assert.Contains(t, stderr, "1 of 2 inputs failed")
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		assert.Equal(t, 2, len(lines))
+
+		var line map[string]string
+		assert.Nil(t, json.Unmarshal([]byte(lines[0]), &line))
+		assert.Equal(t, map[string]string{"file": filepath.Join(dir, "a.pdf"), "result": "text of a.pdf\n"}, line)
+		assert.Nil(t, json.Unmarshal([]byte(lines[1]), &line))
+		assert.Equal(t, "cannot convert", line["error"])
+	})
+
+	t.Run("Stdin used twice", func(t *testing.T) {
+		factory, _, _, _ := setupClients()
+
+		code, _, stderr := runTest([]string{"text", "-", "-"}, "", factory)
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, "stdin can only be used once")
+	})
+
+	t.Run("Client error", func(t *testing.T) {
+		factory, _, _, _ := setupClients()
+		factory.text = func() (pdf2x.TextClient, error) { return nil, fmt.Errorf("version error") }
+		dir := writeTestFiles(t, "a.pdf")
+
+		code, _, stderr := runTest([]string{"text", filepath.Join(dir, "a.pdf")}, "", factory)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "version error")
+	})
+}
+
+func TestRunWords(t *testing.T) {
+	factory, _, _, _ := setupClients()
+	dir := writeTestFiles(t, "a.pdf")
+
+	code, stdout, _ := runTest([]string{"words", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "1\t1.00\t2.00\t3.00\t4.00\tTest\n", stdout)
+
+	code, stdout, _ = runTest([]string{"words", "-json", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+
+	pages := []pdf2text.BboxPage{}
+	assert.Nil(t, json.Unmarshal([]byte(stdout), &pages))
+	assert.Equal(t, "Test", pages[0].Words[0].Text)
+}
+
+func TestRunHTMLAndXML(t *testing.T) {
+	factory, _, htmlClient, _ := setupClients()
+	dir := writeTestFiles(t, "a.pdf")
+
+	code, stdout, _ := runTest([]string{"html", "-s", "-zoom", "2", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "<html></html>", stdout)
+	assert.Equal(t, pdf2html.Options{SingleDoc: true, Zoom: pointerHelperFn(float32(2))}, htmlClient.calls[0])
+
+	code, stdout, _ = runTest([]string{"xml", "-i", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "<pdf2xml></pdf2xml>", stdout)
+	assert.Equal(t, pdf2html.Options{IgnoreImages: true, Xml: true}, htmlClient.calls[1])
+
+	code, stdout, _ = runTest([]string{"json", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "\"poppler\"")
+
+	// pdftohtml prints nothing with -q
+	code, stdout, _ = runTest([]string{"html", "-q", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "<html></html>", stdout)
+	assert.Equal(t, pdf2html.Options{Quite: true}, htmlClient.calls[3])
+
+	code, stdout, _ = runTest([]string{"xml", "-q", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "<pdf2xml></pdf2xml>", stdout)
+	assert.Equal(t, pdf2html.Options{Quite: true, Xml: true}, htmlClient.calls[4])
+}
+
+func TestRunTables(t *testing.T) {
+	factory, _, _, _ := setupClients()
+	dir := writeTestFiles(t, "a.pdf")
+
+	code, stdout, _ := runTest([]string{"tables", "-columns", "100,200", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "A1\tBold B1\nA2\t\n", stdout)
+
+	code, _, stderr := runTest([]string{"tables", "-page", "2", "-columns", "100,200", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "cannot find page 2")
+
+	code, _, stderr = runTest([]string{"tables", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "the -columns flag is required")
+
+	code, _, _ = runTest([]string{"tables", "-columns", "100,abc", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 2, code)
+}
+
+func TestRunInfoAndOutline(t *testing.T) {
+	factory, _, _, infoClient := setupClients()
+	dir := writeTestFiles(t, "a.pdf")
+
+	code, stdout, _ := runTest([]string{"info", "-isodates", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "Pages: 1\nProducer: poppler\n", stdout)
+	assert.Equal(t, pdfinfo.Options{IsoDates: true}, infoClient.calls[0])
+
+	code, stdout, _ = runTest([]string{"outline", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "Chapter 1 (1)\n  Section 1.1 (1)\n", stdout)
+}
+
+func TestRunMarkdown(t *testing.T) {
+	factory, _, _, _ := setupClients()
+	dir := writeTestFiles(t, "a.pdf")
+
+	code, stdout, _ := runTest([]string{"markdown", "-pagebreaks", filepath.Join(dir, "a.pdf")}, "", factory)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "A1\n\n**A**_2_\n\n**Bold** B1\n", stdout)
+}
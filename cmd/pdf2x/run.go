@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2x"
+)
+
+const stdinInput = "-"
+
+// Output of a conversion, Text is used for plain output and Value for JSON output
+type result struct {
+	Text  string
+	Value any
+}
+
+type batchOptions struct {
+	Jobs int  // number of inputs converted in parallel
+	JSON bool // write JSON instead of plain output
+}
+
+type batchEntry struct {
+	result *result
+	err    error
+}
+
+// Expands the glob patterns of the arguments. Without arguments stdin is used
+func expandInputs(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return []string{stdinInput}, nil
+	}
+
+	inputs := []string{}
+	stdinCount := 0
+	for _, arg := range args {
+		if arg == stdinInput {
+			stdinCount++
+			inputs = append(inputs, arg)
+			continue
+		}
+
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", arg, err)
+		}
+
+		// Keep inputs without matches, so the conversion reports the missing file
+		if len(matches) == 0 {
+			matches = []string{arg}
+		}
+
+		inputs = append(inputs, matches...)
+	}
+
+	if stdinCount > 1 {
+		return nil, fmt.Errorf("stdin can only be used once")
+	}
+
+	return inputs, nil
+}
+
+// Runs the conversion for every input and writes the results in the order of the inputs
+func runBatch(inputs []string, options batchOptions, stdin io.Reader, stdout, stderr io.Writer, convert func(path string) (*result, error)) error {
+	jobs := options.Jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	entries := make([]batchEntry, len(inputs))
+	done := make([]chan struct{}, len(inputs))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				entries[i].result, entries[i].err = convertInput(inputs[i], stdin, convert)
+				close(done[i])
+			}
+		}()
+	}
+
+	go func() {
+		for i := range inputs {
+			queue <- i
+		}
+		close(queue)
+	}()
+
+	failed := 0
+	for i, input := range inputs {
+		<-done[i]
+
+		err := writeEntry(input, entries[i], len(inputs) > 1, options.JSON, stdout)
+		if err != nil {
+			return err
+		}
+		if entries[i].err != nil {
+			failed++
+			fmt.Fprintf(stderr, "pdf2x: %s: %s\n", input, entries[i].err)
+		}
+	}
+
+	wg.Wait()
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d inputs failed", failed, len(inputs))
+	}
+
+	return nil
+}
+
+func convertInput(input string, stdin io.Reader, convert func(path string) (*result, error)) (*result, error) {
+	if input != stdinInput {
+		return convert(input)
+	}
+
+	doc, err := pdf2x.OpenReader(stdin, pdf2x.OpenOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer doc.Close()
+
+	return convert(doc.Path())
+}
+
+func writeEntry(input string, entry batchEntry, batch, asJSON bool, stdout io.Writer) error {
+	if asJSON {
+		return writeJSONEntry(input, entry, batch, stdout)
+	}
+
+	if entry.err != nil {
+		return nil
+	}
+
+	if batch {
+		_, err := fmt.Fprintf(stdout, "==> %s <==\n", input)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(stdout, entry.result.Text)
+	return err
+}
+
+func writeJSONEntry(input string, entry batchEntry, batch bool, stdout io.Writer) error {
+	encoder := json.NewEncoder(stdout)
+
+	// A single input is written as the plain value, batches as one object per line
+	if !batch {
+		if entry.err != nil {
+			return nil
+		}
+
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entry.result.Value)
+	}
+
+	line := struct {
+		File   string `json:"file"`
+		Result any    `json:"result,omitempty"`
+		Error  string `json:"error,omitempty"`
+	}{
+		File: input,
+	}
+
+	if entry.err != nil {
+		line.Error = entry.err.Error()
+	} else {
+		line.Result = entry.result.Value
+	}
+
+	return encoder.Encode(line)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nextunit-io/go-pdf2X/server"
+)
+
+var listenAndServe = http.ListenAndServe
+
+// Runs the HTTP conversion server and returns the exit code
+func runServe(args []string, stderr io.Writer, factory clients) int {
+	fs := flag.NewFlagSet("pdf2x serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	addr := fs.String("addr", ":8080", "address the server listens on")
+	maxBodySize := fs.Int64("max-body", 32<<20, "maximum size of a request body in bytes")
+	timeout := fs.Duration("timeout", 60*time.Second, "maximum duration of a request")
+	concurrency := fs.Int("concurrency", 0, "maximum number of conversions running at the same time (default number of CPUs)")
+
+	err := fs.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	set := &clientSet{factory: factory}
+	textClient, err := set.textClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf2x: %s\n", err)
+		return 1
+	}
+	htmlClient, err := set.htmlClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf2x: %s\n", err)
+		return 1
+	}
+	infoClient, err := set.infoClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf2x: %s\n", err)
+		return 1
+	}
+
+	s, err := server.New(server.Config{
+		MaxBodySize:    *maxBodySize,
+		Timeout:        *timeout,
+		MaxConcurrency: *concurrency,
+		TextClient:     textClient,
+		HTMLClient:     htmlClient,
+		InfoClient:     infoClient,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf2x: %s\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "pdf2x: listening on %s\n", *addr)
+	err = listenAndServe(*addr, s.Handler())
+	if err != nil {
+		fmt.Fprintf(stderr, "pdf2x: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2x"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunServe(t *testing.T) {
+	defer func() { listenAndServe = http.ListenAndServe }()
+
+	t.Run("Serve with flags", func(t *testing.T) {
+		factory, _, _, _ := setupClients()
+
+		var handler http.Handler
+		listenAndServe = func(addr string, h http.Handler) error {
+			assert.Equal(t, ":9090", addr)
+			handler = h
+			return nil
+		}
+
+		code, _, stderr := runTest([]string{"serve", "-addr", ":9090", "-timeout", "5s", "-concurrency", "2"}, "", factory)
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stderr, "listening on :9090")
+
+		req := httptest.NewRequest(http.MethodPost, "/info", strings.NewReader("%PDF"))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Listen error", func(t *testing.T) {
+		factory, _, _, _ := setupClients()
+		listenAndServe = func(addr string, h http.Handler) error {
+			return fmt.Errorf("address in use")
+		}
+
+		code, _, stderr := runTest([]string{"serve"}, "", factory)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "address in use")
+	})
+
+	t.Run("Client error", func(t *testing.T) {
+		factory, _, _, _ := setupClients()
+		factory.info = func() (pdf2x.InfoClient, error) { return nil, fmt.Errorf("version error") }
+
+		code, _, stderr := runTest([]string{"serve"}, "", factory)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "version error")
+	})
+
+	t.Run("Invalid flag", func(t *testing.T) {
+		factory, _, _, _ := setupClients()
+
+		code, _, _ := runTest([]string{"serve", "-timeout", "abc"}, "", factory)
+		assert.Equal(t, 2, code)
+	})
+}
@@ -0,0 +1,224 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Steps of the normalisation, they run in the order of the fields
+type Options struct {
+	Control     bool // remove control characters except tabs, line feeds, carriage returns and form feeds
+	NFC         bool // compose the characters (Unicode NFC)
+	NFKC        bool // compose the characters and replace compatibility characters, e.g. ² by 2 (Unicode NFKC), replaces NFC
+	Ligatures   bool // expand ligatures, e.g. ﬁ to fi
+	SoftHyphens bool // remove soft hyphens and join the words of a soft hyphen at the end of a line
+	Hyphenation bool // join words hyphenated at the end of a line, the rest of the word is moved to the first line. Compounds like state-of-the-art keep the hyphen
+	Quotes      bool // replace typographic quotes and apostrophes by ' and "
+	Whitespace  bool // replace spaces by a single space and remove spaces around line breaks
+}
+
+// Name of a step of the normalisation
+type Step string
+
+const (
+	StepControl     Step = "control"
+	StepNFC         Step = "nfc"
+	StepNFKC        Step = "nfkc"
+	StepLigatures   Step = "ligatures"
+	StepSoftHyphens Step = "softHyphens"
+	StepHyphenation Step = "hyphenation"
+	StepQuotes      Step = "quotes"
+	StepWhitespace  Step = "whitespace"
+)
+
+// Replacement made by a step, equal replacements are counted
+type Change struct {
+	Step  Step   `json:"step"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Count int    `json:"count"`
+}
+
+var (
+	ligatures = map[string]string{
+		"Ĳ": "IJ",
+		"ĳ": "ij",
+		"ﬀ": "ff",
+		"ﬁ": "fi",
+		"ﬂ": "fl",
+		"ﬃ": "ffi",
+		"ﬄ": "ffl",
+		"ﬅ": "st",
+		"ﬆ": "st",
+	}
+	quotes = map[string]string{
+		"‘": "'",
+		"’": "'",
+		"‚": "'",
+		"‛": "'",
+		"“": "\"",
+		"”": "\"",
+		"„": "\"",
+		"‟": "\"",
+	}
+
+	ligatureRegexp    = regexp.MustCompile(`[\x{132}\x{133}\x{fb00}-\x{fb06}]`)
+	quoteRegexp       = regexp.MustCompile(`[\x{2018}-\x{201f}]`)
+	softHyphenRegexp  = regexp.MustCompile(`\x{ad}(?:[ \t]*(\r?\n)[ \t]*([^\s\x{ad}]+)([ \t]*\r?\n|[ \t]+|$))?`)
+	hyphenationRegexp = regexp.MustCompile(`(\p{L}+)([-\x{2010}])[ \t]*(\r?\n)[ \t]*(\p{Ll}\S*)([ \t]*\r?\n|[ \t]+|$)`)
+	spaceRegexp       = regexp.MustCompile(`[\t \x{a0}\x{2000}-\x{200a}\x{202f}\x{205f}\x{3000}]+`)
+	lineBreakRegexp   = regexp.MustCompile(` ?(\r?\n|\f) ?`)
+)
+
+// Get the options with all steps except NFKC, which changes more than the representation of the characters
+func Default() Options {
+	return Options{
+		Control:     true,
+		NFC:         true,
+		Ligatures:   true,
+		SoftHyphens: true,
+		Hyphenation: true,
+		Quotes:      true,
+		Whitespace:  true,
+	}
+}
+
+type changes struct {
+	list  []Change
+	index map[Change]int // index of the change in the list by its step, from and to
+}
+
+func newChanges() *changes {
+	return &changes{list: []Change{}, index: map[Change]int{}}
+}
+
+func (c *changes) add(step Step, from, to string) {
+	if from == to {
+		return
+	}
+
+	key := Change{Step: step, From: from, To: to}
+	if i, ok := c.index[key]; ok {
+		c.list[i].Count++
+		return
+	}
+
+	c.index[key] = len(c.list)
+	key.Count = 1
+	c.list = append(c.list, key)
+}
+
+// Normalises the content with the steps of the options and returns the changes in the order of the steps
+func String(content string, options Options) (string, []Change) {
+	result := newChanges()
+	content = normalize(content, options, result)
+
+	return content, result.list
+}
+
+func normalize(content string, options Options, result *changes) string {
+	if options.Control {
+		content = strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) && !strings.ContainsRune("\t\n\r\f", r) {
+				result.add(StepControl, string(r), "")
+				return -1
+			}
+			return r
+		}, content)
+	}
+
+	switch {
+	case options.NFKC:
+		content = normalizeForm(content, norm.NFKC, StepNFKC, result)
+	case options.NFC:
+		content = normalizeForm(content, norm.NFC, StepNFC, result)
+	}
+
+	if options.Ligatures {
+		content = replace(content, ligatureRegexp, StepLigatures, result, func(match []string) string {
+			return ligatures[match[0]]
+		})
+	}
+
+	if options.SoftHyphens {
+		content = replace(content, softHyphenRegexp, StepSoftHyphens, result, func(match []string) string {
+			if match[1] == "" {
+				return ""
+			}
+			return match[2] + lineEnd(match[3], match[1])
+		})
+	}
+
+	if options.Hyphenation {
+		content = replace(content, hyphenationRegexp, StepHyphenation, result, func(match []string) string {
+			// A hyphen in the rest of the word marks a compound, the hyphen at the end of the line is part of it
+			if strings.ContainsAny(match[4], "-\u2010") {
+				return match[1] + match[2] + match[4] + lineEnd(match[5], match[3])
+			}
+			return match[1] + match[4] + lineEnd(match[5], match[3])
+		})
+	}
+
+	if options.Quotes {
+		content = replace(content, quoteRegexp, StepQuotes, result, func(match []string) string {
+			return quotes[match[0]]
+		})
+	}
+
+	if options.Whitespace {
+		content = replace(content, spaceRegexp, StepWhitespace, result, func(match []string) string {
+			return " "
+		})
+		content = replace(content, lineBreakRegexp, StepWhitespace, result, func(match []string) string {
+			return match[1]
+		})
+	}
+
+	return content
+}
+
+// Get the end of the joined word, the line break stays after the word if it is not the end of the content
+func lineEnd(end, lineBreak string) string {
+	if end == "" {
+		return ""
+	}
+	return lineBreak
+}
+
+// Replaces the matches of the regular expression and adds the changes
+func replace(content string, expression *regexp.Regexp, step Step, result *changes, fn func(match []string) string) string {
+	return expression.ReplaceAllStringFunc(content, func(match string) string {
+		to := fn(expression.FindStringSubmatch(match))
+		result.add(step, match, to)
+		return to
+	})
+}
+
+// Normalises the content to the form, every changed segment is a change
+func normalizeForm(content string, form norm.Form, step Step, result *changes) string {
+	if form.IsNormalString(content) {
+		return content
+	}
+
+	var builder strings.Builder
+	var iter norm.Iter
+	iter.InitString(form, content)
+	segment := []byte{}
+	for start := 0; !iter.Done(); {
+		// A decomposed character can be returned in several segments
+		segment = append(segment, iter.Next()...)
+		if iter.Pos() == start {
+			continue
+		}
+
+		result.add(step, content[start:iter.Pos()], string(segment))
+		builder.Write(segment)
+		segment = segment[:0]
+		start = iter.Pos()
+	}
+
+	return builder.String()
+}
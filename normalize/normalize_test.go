@@ -0,0 +1,63 @@
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/normalize"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString(t *testing.T) {
+	t.Run("default steps", func(t *testing.T) {
+		content, changes := normalize.String("The ﬁrst “ofﬁce”\x00 is  café.\nA long exam-\nple text with soft hy­phens and a hy­\nphen \t\n\fend", normalize.Default())
+
+		assert.Equal(t, "The first \"office\" is café.\nA long example\ntext with soft hyphens and a hyphen\n\fend", content)
+		assert.Equal(t, []normalize.Change{
+			{Step: normalize.StepControl, From: "\x00", To: "", Count: 1},
+			{Step: normalize.StepNFC, From: "é", To: "é", Count: 1},
+			{Step: normalize.StepLigatures, From: "ﬁ", To: "fi", Count: 2},
+			{Step: normalize.StepSoftHyphens, From: "­", To: "", Count: 1},
+			{Step: normalize.StepSoftHyphens, From: "­\nphen \t\n", To: "phen\n", Count: 1},
+			{Step: normalize.StepHyphenation, From: "exam-\nple ", To: "example\n", Count: 1},
+			{Step: normalize.StepQuotes, From: "“", To: "\"", Count: 1},
+			{Step: normalize.StepQuotes, From: "”", To: "\"", Count: 1},
+			{Step: normalize.StepWhitespace, From: "  ", To: " ", Count: 1},
+		}, changes)
+	})
+
+	t.Run("single steps", func(t *testing.T) {
+		content, changes := normalize.String("ﬁ x²  ", normalize.Options{NFKC: true})
+		assert.Equal(t, "fi x2  ", content)
+		assert.Len(t, changes, 2)
+
+		content, changes = normalize.String("a \t b \n c\u00a0 d\n", normalize.Options{Whitespace: true})
+		assert.Equal(t, "a b\nc d\n", content)
+		assert.Equal(t, []normalize.Change{
+			{Step: normalize.StepWhitespace, From: " \t ", To: " ", Count: 1},
+			{Step: normalize.StepWhitespace, From: "\u00a0 ", To: " ", Count: 1},
+			{Step: normalize.StepWhitespace, From: " \n ", To: "\n", Count: 1},
+		}, changes)
+
+		content, _ = normalize.String("Baden-\nWürttemberg and self-\nmade", normalize.Options{Hyphenation: true})
+		assert.Equal(t, "Baden-\nWürttemberg and selfmade", content)
+
+		// Compounds keep their hyphens, punctuation is moved with the rest of the word
+		content, changes = normalize.String("a state-\nof-the-art design and an exam-\nple.\nEnd", normalize.Options{Hyphenation: true})
+		assert.Equal(t, "a state-of-the-art\ndesign and an example.\nEnd", content)
+		assert.Equal(t, []normalize.Change{
+			{Step: normalize.StepHyphenation, From: "state-\nof-the-art ", To: "state-of-the-art\n", Count: 1},
+			{Step: normalize.StepHyphenation, From: "exam-\nple.\n", To: "example.\n", Count: 1},
+		}, changes)
+
+		// The step is switched off like every other step
+		options := normalize.Default()
+		options.Hyphenation = false
+		content, changes = normalize.String("exam-\nple", options)
+		assert.Equal(t, "exam-\nple", content)
+		assert.Empty(t, changes)
+
+		content, changes = normalize.String("‘a’  ﬁ", normalize.Options{})
+		assert.Equal(t, "‘a’  ﬁ", content)
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,57 @@
+package normalize
+
+import (
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+)
+
+// Client interface for pdftotext, implemented by pdf2text.Client
+type TextClient interface {
+	Get(filePath string, options pdf2text.Options) (*string, error)
+}
+
+// Get the normalised content of pdf2text.Client.Get and the changes
+func Text(client TextClient, filePath string, textOptions pdf2text.Options, options Options) (*string, []Change, error) {
+	out, err := client.Get(filePath, textOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	if out == nil {
+		return nil, []Change{}, nil
+	}
+
+	content, changes := String(*out, options)
+	return &content, changes, nil
+}
+
+// Normalises the texts of all pages in place and returns the changes. Text, BoldText and the runs
+// are normalised one by one, so spaces at their start and end are kept and words hyphenated at the
+// end of a text are not joined with the next text
+func XML(data *pdf2html.PdfXmlData, options Options) []Change {
+	result := newChanges()
+	for p := range data.Pages {
+		for t := range data.Pages[p].Texts {
+			text := &data.Pages[p].Texts[t]
+
+			report := result
+			if len(text.Runs) != 0 {
+				for r := range text.Runs {
+					text.Runs[r].Text = normalize(text.Runs[r].Text, options, result)
+				}
+				// Text and BoldText are part of the runs, their changes are counted once
+				report = newChanges()
+			}
+
+			if text.Text != nil {
+				content := normalize(*text.Text, options, report)
+				text.Text = &content
+			}
+			if text.BoldText != nil {
+				content := normalize(*text.BoldText, options, report)
+				text.BoldText = &content
+			}
+		}
+	}
+
+	return result.list
+}
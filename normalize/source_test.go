@@ -0,0 +1,70 @@
+package normalize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/normalize"
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/stretchr/testify/assert"
+)
+
+func pointerHelperFn[T any](x T) *T {
+	return &x
+}
+
+type textClientMock struct {
+	out *string
+	err error
+}
+
+func (m textClientMock) Get(filePath string, options pdf2text.Options) (*string, error) {
+	return m.out, m.err
+}
+
+func TestText(t *testing.T) {
+	content, changes, err := normalize.Text(textClientMock{out: pointerHelperFn("ﬁle\n\f")}, "file.pdf", pdf2text.Options{}, normalize.Default())
+	assert.Nil(t, err)
+	assert.Equal(t, "file\n\f", *content)
+	assert.Len(t, changes, 1)
+
+	content, changes, err = normalize.Text(textClientMock{}, "file.pdf", pdf2text.Options{}, normalize.Default())
+	assert.Nil(t, err)
+	assert.Nil(t, content)
+	assert.Empty(t, changes)
+
+	_, _, err = normalize.Text(textClientMock{err: errors.New("GENERAL ERROR")}, "file.pdf", pdf2text.Options{}, normalize.Default())
+	assert.Equal(t, "GENERAL ERROR", err.Error())
+}
+
+func TestXML(t *testing.T) {
+	data := pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{{Texts: []pdf2html.PdfXmlText{
+		{Text: pointerHelperFn("Oﬃce  ")},
+		{
+			Text:     pointerHelperFn(" ﬁle"),
+			BoldText: pointerHelperFn("“Bold”"),
+			Runs: []pdf2html.PdfXmlRun{
+				{Text: "“Bold”", Bold: true},
+				{Text: " ﬁle"},
+			},
+		},
+		{},
+	}}}}
+
+	changes := normalize.XML(&data, normalize.Default())
+
+	assert.Equal(t, "Office ", *data.Pages[0].Texts[0].Text)
+	assert.Equal(t, " file", *data.Pages[0].Texts[1].Text)
+	assert.Equal(t, "\"Bold\"", *data.Pages[0].Texts[1].BoldText)
+	assert.Equal(t, "\"Bold\" file", data.Pages[0].Texts[1].Content())
+	assert.Nil(t, data.Pages[0].Texts[2].Text)
+
+	assert.Equal(t, []normalize.Change{
+		{Step: normalize.StepLigatures, From: "ﬃ", To: "ffi", Count: 1},
+		{Step: normalize.StepWhitespace, From: "  ", To: " ", Count: 1},
+		{Step: normalize.StepQuotes, From: "“", To: "\"", Count: 1},
+		{Step: normalize.StepQuotes, From: "”", To: "\"", Count: 1},
+		{Step: normalize.StepLigatures, From: "ﬁ", To: "fi", Count: 1},
+	}, changes)
+}
@@ -0,0 +1,295 @@
+package ocr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/go-version"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-tools/tools"
+)
+
+type Client struct {
+	execClient  tools.ExecInterface
+	wrapperFunc func(cmd *exec.Cmd, stdout, stderr io.Writer) CmdWrapper
+}
+
+type CmdWrapper interface {
+	Run() error
+}
+
+// Client interface for pdftotext, implemented by pdf2text.Client
+type TextClient interface {
+	GetBbox(filePath string, options pdf2text.Options) ([]pdf2text.BboxPage, error)
+}
+
+type Options struct {
+	Resolution    *int    // resolution of the rendered pages, in DPI (default is 300)
+	Language      *string // tesseract languages, e.g. deu+eng (default is eng)
+	PageSegMode   *int    // tesseract page segmentation mode
+	MinConfidence float64 // words with a lower tesseract confidence (0 - 100) are skipped
+	OwnerPassword *string // owner password (for encrypted files)
+	UserPassword  *string // user password (for encrypted files)
+}
+
+var wrapCmd func(cmd *exec.Cmd, stdout, stderr io.Writer) CmdWrapper = func(cmd *exec.Cmd, stdout, stderr io.Writer) CmdWrapper {
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd
+}
+
+const (
+	render_cli = "pdftoppm"
+	ocr_cli    = "tesseract"
+
+	versionCheck          = ">= 24.11.0, < 25.0"
+	tesseractVersionCheck = ">= 4.0"
+
+	defaultResolution = 300
+)
+
+// check if the versions of pdftoppm and tesseract are working with this library
+func (c Client) checkVersion() error {
+	checks := []struct {
+		cli        string
+		constraint string
+		get        func() (*string, error)
+	}{
+		{cli: render_cli, constraint: versionCheck, get: c.GetVersion},
+		{cli: ocr_cli, constraint: tesseractVersionCheck, get: c.GetTesseractVersion},
+	}
+
+	for _, check := range checks {
+		v, err := check.get()
+		if err != nil {
+			return fmt.Errorf("cannot check version of %s", check.cli)
+		}
+
+		versionObj, err := version.NewVersion(*v)
+		if err != nil {
+			return err
+		}
+
+		versionConstraint, err := version.NewConstraint(check.constraint)
+		if err != nil {
+			return err
+		}
+
+		// Builds like 5.0.0-alpha are checked by their release
+		if !versionConstraint.Check(versionObj.Core()) {
+			return fmt.Errorf("version %s of %s does not pass the version constraint %s", *v, check.cli, check.constraint)
+		}
+	}
+
+	return nil
+}
+
+// Execute function. Some outputs are using the stdin, some the stderr.
+// Therefore the three return values are representating stdout, stderr, error
+func (c Client) exec(cli string, args ...string) (*string, *string, error) {
+	cmd := tools.GetExecInstance().Command(cli, args...)
+
+	var outBuffer bytes.Buffer
+	var errBuffer bytes.Buffer
+	cmd.Stdout = &outBuffer
+	cmd.Stderr = &errBuffer
+
+	wrappedCmd := c.wrapperFunc(cmd, &outBuffer, &errBuffer)
+
+	err := wrappedCmd.Run()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputString := outBuffer.String()
+	errorString := errBuffer.String()
+
+	var outputStrPtr *string = nil
+	var errorStrPtr *string = nil
+
+	if outputString != "" {
+		outputStrPtr = &outputString
+	}
+	if errorString != "" {
+		errorStrPtr = &errorString
+	}
+
+	return outputStrPtr, errorStrPtr, nil
+}
+
+// Get the words with their bounding boxes of the given pages by OCR. Every page is rendered with
+// pdftoppm and recognised with tesseract, the boxes are converted to points like the ones of
+// pdf2text.Client.GetBbox
+func (c Client) GetBbox(filePath string, pages []int, options Options) ([]pdf2text.BboxPage, error) {
+	resolution := defaultResolution
+	if options.Resolution != nil {
+		resolution = *options.Resolution
+	}
+
+	dir, err := tools.GetOsInstance().MkdirTemp(tools.GetOsInstance().TempDir(), "ocr-*")
+	if err != nil {
+		return nil, err
+	}
+	defer tools.GetOsInstance().RemoveAll(dir)
+
+	result := []pdf2text.BboxPage{}
+	for _, page := range pages {
+		prefix := filepath.Join(dir, fmt.Sprintf("page-%d", page))
+
+		args := []string{"-f", strconv.Itoa(page), "-l", strconv.Itoa(page), "-r", strconv.Itoa(resolution), "-png", "-singlefile"}
+		if options.OwnerPassword != nil {
+			args = append(args, "-opw", *options.OwnerPassword)
+		}
+		if options.UserPassword != nil {
+			args = append(args, "-upw", *options.UserPassword)
+		}
+		args = append(args, filePath, prefix)
+
+		_, e, err := c.exec(render_cli, args...)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			return nil, fmt.Errorf("channel: %s", *e)
+		}
+
+		// tesseract writes its progress to stderr, only the exit code tells about errors
+		args = []string{fmt.Sprintf("%s.png", prefix), "stdout", "--dpi", strconv.Itoa(resolution)}
+		if options.Language != nil {
+			args = append(args, "-l", *options.Language)
+		}
+		if options.PageSegMode != nil {
+			args = append(args, "--psm", strconv.Itoa(*options.PageSegMode))
+		}
+		args = append(args, "tsv")
+
+		out, _, err := c.exec(ocr_cli, args...)
+		if err != nil {
+			return nil, err
+		}
+		if out == nil {
+			return nil, fmt.Errorf("no output of %s for page %d", ocr_cli, page)
+		}
+
+		bboxPage, err := ParseTSV(*out, page, resolution, options.MinConfidence)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, bboxPage)
+	}
+
+	return result, nil
+}
+
+// Get the words with their bounding boxes of the text layer and of the OCR for the pages without
+// text layer, see ScannedBbox. The numbers of the recognised pages are returned as well
+func (c Client) GetBboxWithFallback(textClient TextClient, filePath string, textOptions pdf2text.Options, detect DetectOptions, options Options) ([]pdf2text.BboxPage, []int, error) {
+	pages, err := textClient.GetBbox(filePath, textOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scanned := ScannedBbox(pages, detect)
+	if len(scanned) == 0 {
+		return pages, scanned, nil
+	}
+
+	if options.OwnerPassword == nil {
+		options.OwnerPassword = textOptions.OwnerPassword
+	}
+	if options.UserPassword == nil {
+		options.UserPassword = textOptions.UserPassword
+	}
+
+	recognised, err := c.GetBbox(filePath, scanned, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byNumber := map[int]pdf2text.BboxPage{}
+	for _, page := range recognised {
+		byNumber[page.Number] = page
+	}
+	for i, page := range pages {
+		if r, ok := byNumber[page.Number]; ok {
+			pages[i] = r
+		}
+	}
+
+	return pages, scanned, nil
+}
+
+// Get the current pdftoppm version
+func (c Client) GetVersion() (*string, error) {
+	_, out, err := c.exec(render_cli, "-v")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out == nil {
+		return nil, fmt.Errorf("cannot find the version")
+	}
+
+	r := regexp.MustCompile("pdftoppm version ([^\n]+)\n")
+	matches := r.FindStringSubmatch(*out)
+	if len(matches) != 2 {
+		return nil, fmt.Errorf("cannot find the version")
+	}
+
+	return &matches[1], nil
+}
+
+// Get the current tesseract version
+func (c Client) GetTesseractVersion() (*string, error) {
+	out, e, err := c.exec(ocr_cli, "--version")
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Older versions print the version to stderr
+	if out == nil {
+		out = e
+	}
+	if out == nil {
+		return nil, fmt.Errorf("cannot find the version")
+	}
+
+	r := regexp.MustCompile(`tesseract v?([0-9][^\s]*)`)
+	matches := r.FindStringSubmatch(*out)
+	if len(matches) != 2 {
+		return nil, fmt.Errorf("cannot find the version")
+	}
+
+	return &matches[1], nil
+}
+
+// Overwrite wrapper function for buffer
+func SetWrapperFunc(fn func(cmd *exec.Cmd, stdout, stderr io.Writer) CmdWrapper) {
+	wrapCmd = fn
+}
+
+// Get the OCR client
+// Will return an error, if the installed pdftoppm or tesseract version is not valid
+func NewClient() (*Client, error) {
+	c := &Client{
+		execClient:  tools.GetExecInstance(),
+		wrapperFunc: wrapCmd,
+	}
+
+	// Check for valid CLI version before
+	// Do not do the check for getting the version
+	err := c.checkVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, err
+}
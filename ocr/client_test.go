@@ -0,0 +1,372 @@
+package ocr_test
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	gomock "github.com/nextunit-io/go-mock"
+	"github.com/nextunit-io/go-pdf2X/ocr"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-tools/tools"
+	"github.com/nextunit-io/go-tools/toolsmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	execMock      *toolsmock.ExecMock
+	wrapperFnMock *gomock.ToolMock[
+		struct {
+			Cmd    *exec.Cmd
+			Stdout io.Writer
+			Stderr io.Writer
+		},
+		ocr.CmdWrapper,
+	]
+	runMock *gomock.ToolMock[
+		interface{},
+		func(cmd []string) (*string, *string, error),
+	]
+)
+
+var tsvContent = "level\tpage_num\tblock_num\tpar_num\tline_num\tword_num\tleft\ttop\twidth\theight\tconf\ttext\n" +
+	"1\t1\t0\t0\t0\t0\t0\t0\t2480\t3508\t-1\t\n" +
+	"2\t1\t1\t0\t0\t0\t300\t300\t1000\t100\t-1\t\n" +
+	"5\t1\t1\t1\t1\t1\t300\t300\t400\t100\t96.5\tScanned\n" +
+	"5\t1\t1\t1\t1\t2\t750\t300\t550\t100\t91\tletter\n" +
+	"5\t1\t1\t1\t1\t3\t1350\t300\t50\t100\t12.25\t~\n" +
+	"5\t1\t1\t1\t1\t4\t1450\t300\t50\t100\t95\t \n"
+
+type testVersionWrapper struct{}
+
+func (testVersionWrapper) Run() error {
+	runMock.AddInput(nil)
+
+	result, err := runMock.GetNextResult()
+	if err != nil {
+		return err
+	}
+	fn := *result
+
+	cmdInput := wrapperFnMock.GetLastInput()
+	outString, errString, err := fn(cmdInput.Cmd.Args)
+
+	if outString != nil {
+		cmdInput.Stdout.Write([]byte(*outString))
+	}
+	if errString != nil {
+		cmdInput.Stderr.Write([]byte(*errString))
+	}
+
+	return err
+}
+
+func pointerHelperFn[T any](x T) *T {
+	return &x
+}
+
+func setupTests() {
+	execMock = toolsmock.GetExecMock()
+	tools.SetExecInstance(execMock)
+
+	// Setup wrapper function mock
+	wrapperFnMock = gomock.GetMock[
+		struct {
+			Cmd    *exec.Cmd
+			Stdout io.Writer
+			Stderr io.Writer
+		},
+		ocr.CmdWrapper,
+	](fmt.Errorf("WRAPPER general error"))
+
+	runMock = gomock.GetMock[interface{}, func(cmd []string) (*string, *string, error)](fmt.Errorf("GENERAL ERROR"))
+
+	ocr.SetWrapperFunc(func(cmd *exec.Cmd, stdout, stderr io.Writer) ocr.CmdWrapper {
+		wrapperFnMock.AddInput(struct {
+			Cmd    *exec.Cmd
+			Stdout io.Writer
+			Stderr io.Writer
+		}{
+			Cmd:    cmd,
+			Stdout: stdout,
+			Stderr: stderr,
+		})
+
+		result, err := wrapperFnMock.GetNextResult()
+		if err != nil {
+			panic(err.Error())
+		}
+
+		return *result
+	})
+
+	execMock.Mock.Command.SetAlwaysReturnFn(func() (**exec.Cmd, error) {
+		lastCommand := execMock.Mock.Command.GetLastInput()
+		cmd := exec.Command(lastCommand.Name, lastCommand.Arg...)
+		return &cmd, nil
+	})
+
+	wrapperFnMock.SetAlwaysReturnFn(func() (*ocr.CmdWrapper, error) {
+		var wrapper ocr.CmdWrapper = &testVersionWrapper{}
+		return &wrapper, nil
+	})
+
+	setupInitialVersion()
+}
+
+func addVersions(renderVersion, ocrVersion string) {
+	renderFn := func(cmd []string) (*string, *string, error) {
+		versionReturnValue := fmt.Sprintf(`pdftoppm version %s
+Copyright 2005-2024 The Poppler Developers - http://poppler.freedesktop.org
+Copyright 1996-2011, 2022 Glyph & Cog, LLC
+`, renderVersion)
+		return nil, &versionReturnValue, nil
+	}
+	ocrFn := func(cmd []string) (*string, *string, error) {
+		versionReturnValue := fmt.Sprintf("tesseract %s\n leptonica-1.84.1\n  libgif 5.2.2 : libjpeg 8d (libjpeg-turbo 3.0.4)\n", ocrVersion)
+		return &versionReturnValue, nil, nil
+	}
+
+	runMock.AddReturnValue(&renderFn)
+	runMock.AddReturnValue(&ocrFn)
+}
+
+func setupInitialVersion() {
+	runMock.Reset()
+	addVersions("24.11.0", "5.5.0")
+}
+
+func addOutput(out, e *string, err error) {
+	fn := func(cmd []string) (*string, *string, error) {
+		return out, e, err
+	}
+	runMock.AddReturnValue(&fn)
+}
+
+func TestGetClient(t *testing.T) {
+	t.Helper()
+	setupTests()
+
+	client, err := ocr.NewClient()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+	assert.Equal(t, []string{"pdftoppm", "-v"}, wrapperFnMock.GetInput(0).Cmd.Args)
+	assert.Equal(t, []string{"tesseract", "--version"}, wrapperFnMock.GetLastInput().Cmd.Args)
+
+	// Second try should fail, because there will be no version sent back upon the second time
+	client, err = ocr.NewClient()
+	assert.Nil(t, client)
+	assert.Equal(t, "cannot check version of pdftoppm", err.Error())
+
+	runMock.Reset()
+	addVersions("24.10.100", "5.5.0")
+	client, err = ocr.NewClient()
+	assert.Nil(t, client)
+	assert.Equal(t, "version 24.10.100 of pdftoppm does not pass the version constraint >= 24.11.0, < 25.0", err.Error())
+
+	runMock.Reset()
+	addVersions("24.11.0", "3.05.02")
+	client, err = ocr.NewClient()
+	assert.Nil(t, client)
+	assert.Equal(t, "version 3.05.02 of tesseract does not pass the version constraint >= 4.0", err.Error())
+
+	// Development builds are checked by their release
+	runMock.Reset()
+	addVersions("24.11.0", "5.0.0-alpha-20201224")
+	client, err = ocr.NewClient()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+}
+
+func TestGetTesseractVersion(t *testing.T) {
+	t.Helper()
+	setupTests()
+	client, _ := ocr.NewClient()
+
+	t.Run("Version on stdout", func(t *testing.T) {
+		runMock.Reset()
+		addOutput(pointerHelperFn("tesseract 5.3.4\n leptonica-1.84.1\n"), nil, nil)
+
+		v, err := client.GetTesseractVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "5.3.4", *v)
+	})
+
+	t.Run("Version on stderr", func(t *testing.T) {
+		runMock.Reset()
+		addOutput(nil, pointerHelperFn("tesseract 4.1.1\n leptonica-1.79.0\n"), nil)
+
+		v, err := client.GetTesseractVersion()
+		assert.Nil(t, err)
+		assert.Equal(t, "4.1.1", *v)
+	})
+
+	t.Run("No version", func(t *testing.T) {
+		runMock.Reset()
+		addOutput(pointerHelperFn("unknown\n"), nil, nil)
+
+		v, err := client.GetTesseractVersion()
+		assert.Nil(t, v)
+		assert.Equal(t, "cannot find the version", err.Error())
+
+		addOutput(nil, nil, nil)
+		v, err = client.GetTesseractVersion()
+		assert.Nil(t, v)
+		assert.Equal(t, "cannot find the version", err.Error())
+
+		v, err = client.GetTesseractVersion()
+		assert.Nil(t, v)
+		assert.Equal(t, "GENERAL ERROR", err.Error())
+	})
+}
+
+func TestGetBbox(t *testing.T) {
+	t.Helper()
+	setupTests()
+	client, _ := ocr.NewClient()
+
+	t.Run("Check for successful get", func(t *testing.T) {
+		runMock.Reset()
+		addOutput(nil, nil, nil)
+		addOutput(&tsvContent, pointerHelperFn("Estimating resolution as 300\n"), nil)
+
+		pages, err := client.GetBbox("filename", []int{2}, ocr.Options{})
+		assert.Nil(t, err)
+		assert.Len(t, pages, 1)
+		assert.Equal(t, 2, pages[0].Number)
+		assert.InDelta(t, 595.2, pages[0].Width, 0.001)
+		assert.InDelta(t, 841.92, pages[0].Height, 0.001)
+		assert.Equal(t, []string{"Scanned", "letter", "~"}, words(pages[0]))
+
+		renderArgs := wrapperFnMock.GetInput(wrapperFnMock.HasBeenCalled() - 2).Cmd.Args
+		prefix := renderArgs[len(renderArgs)-1]
+		assert.Equal(t, "page-2", filepath.Base(prefix))
+		assert.Equal(t, []string{"pdftoppm", "-f", "2", "-l", "2", "-r", "300", "-png", "-singlefile", "filename", prefix}, renderArgs)
+		assert.Equal(t, []string{"tesseract", prefix + ".png", "stdout", "--dpi", "300", "tsv"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Check for all flags", func(t *testing.T) {
+		runMock.Reset()
+		addOutput(nil, nil, nil)
+		addOutput(&tsvContent, nil, nil)
+		addOutput(nil, nil, nil)
+		addOutput(&tsvContent, nil, nil)
+
+		pages, err := client.GetBbox("filename", []int{1, 3}, ocr.Options{
+			Resolution:    pointerHelperFn(150),
+			Language:      pointerHelperFn("deu+eng"),
+			PageSegMode:   pointerHelperFn(6),
+			MinConfidence: 50,
+			OwnerPassword: pointerHelperFn("test-owner-password"),
+			UserPassword:  pointerHelperFn("test-user-password"),
+		})
+		assert.Nil(t, err)
+		assert.Len(t, pages, 2)
+		assert.Equal(t, 3, pages[1].Number)
+		assert.Equal(t, []string{"Scanned", "letter"}, words(pages[1]))
+		assert.InDelta(t, 144, pages[1].Words[0].XMin, 0.001)
+
+		renderArgs := wrapperFnMock.GetInput(wrapperFnMock.HasBeenCalled() - 2).Cmd.Args
+		prefix := renderArgs[len(renderArgs)-1]
+		assert.Equal(t, []string{"pdftoppm",
+			"-f", "3",
+			"-l", "3",
+			"-r", "150",
+			"-png", "-singlefile",
+			"-opw", "test-owner-password",
+			"-upw", "test-user-password",
+			"filename", prefix,
+		}, renderArgs)
+		assert.Equal(t, []string{"tesseract", prefix + ".png", "stdout", "--dpi", "150", "-l", "deu+eng", "--psm", "6", "tsv"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Check for errors", func(t *testing.T) {
+		runMock.Reset()
+		pages, err := client.GetBbox("filename", []int{1}, ocr.Options{})
+		assert.Nil(t, pages)
+		assert.Equal(t, "GENERAL ERROR", err.Error())
+
+		addOutput(nil, pointerHelperFn("Syntax Error: broken file"), nil)
+		pages, err = client.GetBbox("filename", []int{1}, ocr.Options{})
+		assert.Nil(t, pages)
+		assert.Equal(t, "channel: Syntax Error: broken file", err.Error())
+
+		addOutput(nil, nil, nil)
+		pages, err = client.GetBbox("filename", []int{1}, ocr.Options{})
+		assert.Nil(t, pages)
+		assert.Equal(t, "GENERAL ERROR", err.Error())
+
+		addOutput(nil, nil, nil)
+		addOutput(nil, nil, nil)
+		pages, err = client.GetBbox("filename", []int{1}, ocr.Options{})
+		assert.Nil(t, pages)
+		assert.Equal(t, "no output of tesseract for page 1", err.Error())
+
+		addOutput(nil, nil, nil)
+		addOutput(pointerHelperFn("5\t1\n"), nil, nil)
+		pages, err = client.GetBbox("filename", []int{1}, ocr.Options{})
+		assert.Nil(t, pages)
+		assert.Equal(t, "invalid tsv line 1: \"5\\t1\"", err.Error())
+	})
+}
+
+type textClientMock struct {
+	pages   []pdf2text.BboxPage
+	err     error
+	options *pdf2text.Options
+}
+
+func (m *textClientMock) GetBbox(filePath string, options pdf2text.Options) ([]pdf2text.BboxPage, error) {
+	m.options = &options
+	return m.pages, m.err
+}
+
+func TestGetBboxWithFallback(t *testing.T) {
+	t.Helper()
+	setupTests()
+	client, _ := ocr.NewClient()
+
+	textPage := pdf2text.BboxPage{Number: 1, Words: []pdf2text.BboxWord{{Text: "A page with a long text layer"}}}
+
+	t.Run("Pages with text layer", func(t *testing.T) {
+		runMock.Reset()
+		textClient := &textClientMock{pages: []pdf2text.BboxPage{textPage}}
+
+		pages, scanned, err := client.GetBboxWithFallback(textClient, "filename", pdf2text.Options{}, ocr.DetectOptions{}, ocr.Options{})
+		assert.Nil(t, err)
+		assert.Equal(t, []pdf2text.BboxPage{textPage}, pages)
+		assert.Empty(t, scanned)
+		assert.Equal(t, 0, runMock.HasBeenCalled())
+	})
+
+	t.Run("Scanned pages", func(t *testing.T) {
+		runMock.Reset()
+		addOutput(nil, nil, nil)
+		addOutput(&tsvContent, nil, nil)
+		textClient := &textClientMock{pages: []pdf2text.BboxPage{textPage, {Number: 2, Words: []pdf2text.BboxWord{}}}}
+
+		pages, scanned, err := client.GetBboxWithFallback(textClient, "filename", pdf2text.Options{UserPassword: pointerHelperFn("secret")}, ocr.DetectOptions{}, ocr.Options{})
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2}, scanned)
+		assert.Equal(t, textPage, pages[0])
+		assert.Equal(t, []string{"Scanned", "letter", "~"}, words(pages[1]))
+
+		renderArgs := wrapperFnMock.GetInput(wrapperFnMock.HasBeenCalled() - 2).Cmd.Args
+		assert.Equal(t, []string{"-upw", "secret"}, renderArgs[9:11])
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		runMock.Reset()
+		textClient := &textClientMock{err: fmt.Errorf("TEXT ERROR")}
+		_, _, err := client.GetBboxWithFallback(textClient, "filename", pdf2text.Options{}, ocr.DetectOptions{}, ocr.Options{})
+		assert.Equal(t, "TEXT ERROR", err.Error())
+
+		textClient = &textClientMock{pages: []pdf2text.BboxPage{{Number: 1}}}
+		pages, scanned, err := client.GetBboxWithFallback(textClient, "filename", pdf2text.Options{}, ocr.DetectOptions{}, ocr.Options{})
+		assert.Nil(t, pages)
+		assert.Nil(t, scanned)
+		assert.Equal(t, "GENERAL ERROR", err.Error())
+	})
+}
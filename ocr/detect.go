@@ -0,0 +1,127 @@
+package ocr
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+)
+
+type DetectOptions struct {
+	MinChars         int     // pages with fewer characters, without spaces, have no text layer (default 20)
+	MinImageCoverage float64 // share of the page covered by images for ScannedXML (default 0.5)
+}
+
+const (
+	defaultMinChars         = 20
+	defaultMinImageCoverage = 0.5
+)
+
+func (o DetectOptions) withDefaults() DetectOptions {
+	if o.MinChars == 0 {
+		o.MinChars = defaultMinChars
+	}
+	if o.MinImageCoverage == 0 {
+		o.MinImageCoverage = defaultMinImageCoverage
+	}
+
+	return o
+}
+
+// Get the numbers of the pages of the pdftotext output with no or a negligible text layer. The
+// pages are separated by form feeds and numbered starting at firstPage
+func ScannedText(content string, firstPage int, options DetectOptions) []int {
+	options = options.withDefaults()
+
+	pages := strings.Split(content, "\f")
+	if len(pages) > 1 && strings.TrimSpace(pages[len(pages)-1]) == "" {
+		pages = pages[:len(pages)-1]
+	}
+
+	result := []int{}
+	for i, page := range pages {
+		if countChars(page) < options.MinChars {
+			result = append(result, firstPage+i)
+		}
+	}
+
+	return result
+}
+
+// Get the numbers of the pages of the pdftotext -bbox output with no or a negligible text layer
+func ScannedBbox(pages []pdf2text.BboxPage, options DetectOptions) []int {
+	options = options.withDefaults()
+
+	result := []int{}
+	for _, page := range pages {
+		chars := 0
+		for _, word := range page.Words {
+			chars += countChars(word.Text)
+		}
+		if chars < options.MinChars {
+			result = append(result, page.Number)
+		}
+	}
+
+	return result
+}
+
+// Get the numbers of the pages of the XML data with no or a negligible text layer, which are
+// covered by images. Pages without text and images are empty and not scanned. The coverage is
+// the sum of the image areas inside the page, pages without a size have no coverage
+func ScannedXML(data pdf2html.PdfXmlData, options DetectOptions) []int {
+	options = options.withDefaults()
+
+	result := []int{}
+	for i, page := range data.Pages {
+		chars := 0
+		for _, text := range page.Texts {
+			chars += countChars(text.Content())
+		}
+		if chars >= options.MinChars || imageCoverage(page) < options.MinImageCoverage {
+			continue
+		}
+
+		number := i + 1
+		if page.PageNumber != nil {
+			number = *page.PageNumber
+		}
+		result = append(result, number)
+	}
+
+	return result
+}
+
+func countChars(content string) int {
+	chars := 0
+	for _, r := range content {
+		if !unicode.IsSpace(r) {
+			chars++
+		}
+	}
+
+	return chars
+}
+
+// Get the share of the page covered by images, overlapping images are counted twice
+func imageCoverage(page pdf2html.PdfXmlPage) float64 {
+	if page.Width == nil || page.Height == nil || *page.Width <= 0 || *page.Height <= 0 {
+		return 0
+	}
+
+	area := 0
+	for _, image := range page.Images {
+		if image.Top == nil || image.Left == nil || image.Width == nil || image.Height == nil {
+			continue
+		}
+
+		width := min(*image.Left+*image.Width, *page.Width) - max(*image.Left, 0)
+		height := min(*image.Top+*image.Height, *page.Height) - max(*image.Top, 0)
+		if width > 0 && height > 0 {
+			area += width * height
+		}
+	}
+
+	return min(1, float64(area)/float64(*page.Width**page.Height))
+}
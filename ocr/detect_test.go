@@ -0,0 +1,49 @@
+package ocr_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/ocr"
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScannedText(t *testing.T) {
+	content := "A page with a long text layer\n\f \n\n\fShort\n\f"
+
+	assert.Equal(t, []int{4, 5}, ocr.ScannedText(content, 3, ocr.DetectOptions{}))
+	assert.Equal(t, []int{4}, ocr.ScannedText(content, 3, ocr.DetectOptions{MinChars: 5}))
+	assert.Equal(t, []int{1}, ocr.ScannedText("", 1, ocr.DetectOptions{}))
+}
+
+func TestScannedBbox(t *testing.T) {
+	pages := []pdf2text.BboxPage{
+		{Number: 1, Words: []pdf2text.BboxWord{{Text: "Twenty"}, {Text: "characters"}, {Text: "here"}}},
+		{Number: 2, Words: []pdf2text.BboxWord{{Text: " "}, {Text: "3"}}},
+	}
+
+	assert.Equal(t, []int{2}, ocr.ScannedBbox(pages, ocr.DetectOptions{}))
+	assert.Equal(t, []int{1, 2}, ocr.ScannedBbox(pages, ocr.DetectOptions{MinChars: 21}))
+}
+
+func TestScannedXML(t *testing.T) {
+	image := func(top, left, width, height int) pdf2html.PdfXmlImage {
+		return pdf2html.PdfXmlImage{Top: pointerHelperFn(top), Left: pointerHelperFn(left), Width: pointerHelperFn(width), Height: pointerHelperFn(height), Src: pointerHelperFn("image.png")}
+	}
+	page := func(number int, texts []pdf2html.PdfXmlText, images ...pdf2html.PdfXmlImage) pdf2html.PdfXmlPage {
+		return pdf2html.PdfXmlPage{PageNumber: pointerHelperFn(number), Width: pointerHelperFn(100), Height: pointerHelperFn(200), Texts: texts, Images: images}
+	}
+
+	data := pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{
+		page(1, []pdf2html.PdfXmlText{{Text: pointerHelperFn("A page with a text layer and an image")}}, image(0, 0, 100, 200)),
+		page(2, nil, image(-10, -10, 120, 220)),
+		page(3, []pdf2html.PdfXmlText{{Text: pointerHelperFn("12")}}, image(0, 0, 100, 80), image(100, 0, 100, 80)),
+		page(4, nil, image(0, 0, 100, 60)),
+		page(5, nil),
+		{Images: []pdf2html.PdfXmlImage{image(0, 0, 100, 200)}},
+	}}
+
+	assert.Equal(t, []int{2, 3}, ocr.ScannedXML(data, ocr.DetectOptions{}))
+	assert.Equal(t, []int{2, 3, 4}, ocr.ScannedXML(data, ocr.DetectOptions{MinImageCoverage: 0.25}))
+}
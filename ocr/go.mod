@@ -0,0 +1,37 @@
+module github.com/nextunit-io/go-pdf2X/ocr
+
+go 1.23.3
+
+require (
+	github.com/hashicorp/go-version v1.7.0
+	github.com/nextunit-io/go-mock v0.0.0-20240911152234-c0b0103a4eca
+	github.com/nextunit-io/go-pdf2X/pdf2html v0.0.0
+	github.com/nextunit-io/go-pdf2X/pdf2text v0.0.0
+	github.com/nextunit-io/go-tools/tools v0.0.0-20241207211807-bb8694aa99e6
+	github.com/nextunit-io/go-tools/toolsmock v0.0.0-20241207211650-5a9f81c77971
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.32.6 // indirect
+	github.com/aws/aws-sdk-go-v2/config v1.28.6 // indirect
+	github.com/aws/aws-sdk-go-v2/credentials v1.17.47 // indirect
+	github.com/aws/aws-sdk-go-v2/feature/ec2/imds v1.16.21 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/configsources v1.3.25 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.6.25 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/ini v1.8.1 // indirect
+	github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.12.1 // indirect
+	github.com/aws/aws-sdk-go-v2/service/internal/presigned-url v1.12.6 // indirect
+	github.com/aws/aws-sdk-go-v2/service/sso v1.24.7 // indirect
+	github.com/aws/aws-sdk-go-v2/service/ssooidc v1.28.6 // indirect
+	github.com/aws/aws-sdk-go-v2/service/sts v1.33.2 // indirect
+	github.com/aws/smithy-go v1.22.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/nextunit-io/go-pdf2X/pdf2html => ../pdf2html
+	github.com/nextunit-io/go-pdf2X/pdf2text => ../pdf2text
+)
@@ -0,0 +1,74 @@
+package ocr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+)
+
+const (
+	tsvLevelPage = 1
+	tsvLevelWord = 5
+
+	tsvColumns = 11 // columns up to the confidence, the text is the twelfth column
+
+	pointsPerInch = 72
+)
+
+// Parses the tesseract TSV output of a page rendered with the resolution in DPI. The boxes are
+// converted from pixels to points, words with a confidence below minConfidence are skipped
+func ParseTSV(content string, number, resolution int, minConfidence float64) (pdf2text.BboxPage, error) {
+	page := pdf2text.BboxPage{Number: number, Words: []pdf2text.BboxWord{}}
+	if resolution <= 0 {
+		return page, fmt.Errorf("invalid resolution %d", resolution)
+	}
+	points := func(pixels float64) float64 {
+		return pixels * pointsPerInch / float64(resolution)
+	}
+
+	for i, line := range strings.Split(strings.TrimRight(content, "\r\n"), "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if i == 0 && len(fields) != 0 && fields[0] == "level" {
+			continue
+		}
+		if len(fields) < tsvColumns {
+			return page, fmt.Errorf("invalid tsv line %d: %q", i+1, line)
+		}
+
+		numbers := make([]float64, tsvColumns)
+		for c := range numbers {
+			value, err := strconv.ParseFloat(fields[c], 64)
+			if err != nil {
+				return page, fmt.Errorf("invalid tsv line %d: %w", i+1, err)
+			}
+			numbers[c] = value
+		}
+		level, left, top, width, height, confidence := int(numbers[0]), numbers[6], numbers[7], numbers[8], numbers[9], numbers[10]
+
+		switch level {
+		case tsvLevelPage:
+			page.Width = points(width)
+			page.Height = points(height)
+		case tsvLevelWord:
+			text := ""
+			if len(fields) > tsvColumns {
+				text = strings.TrimSpace(strings.Join(fields[tsvColumns:], "\t"))
+			}
+			if text == "" || confidence < minConfidence {
+				continue
+			}
+
+			page.Words = append(page.Words, pdf2text.BboxWord{
+				XMin: points(left),
+				YMin: points(top),
+				XMax: points(left + width),
+				YMax: points(top + height),
+				Text: text,
+			})
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,57 @@
+package ocr_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/ocr"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/stretchr/testify/assert"
+)
+
+func words(page pdf2text.BboxPage) []string {
+	result := []string{}
+	for _, word := range page.Words {
+		result = append(result, word.Text)
+	}
+	return result
+}
+
+func TestParseTSV(t *testing.T) {
+	t.Run("Words in points", func(t *testing.T) {
+		page, err := ocr.ParseTSV(tsvContent, 4, 300, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, pdf2text.BboxPage{
+			Number: 4,
+			Width:  595.2,
+			Height: 841.92,
+			Words: []pdf2text.BboxWord{
+				{XMin: 72, YMin: 72, XMax: 168, YMax: 96, Text: "Scanned"},
+				{XMin: 180, YMin: 72, XMax: 312, YMax: 96, Text: "letter"},
+				{XMin: 324, YMin: 72, XMax: 336, YMax: 96, Text: "~"},
+			},
+		}, page)
+	})
+
+	t.Run("Minimum confidence", func(t *testing.T) {
+		page, err := ocr.ParseTSV(tsvContent, 1, 300, 92)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Scanned"}, words(page))
+	})
+
+	t.Run("Empty output", func(t *testing.T) {
+		page, err := ocr.ParseTSV("level\tpage_num\tblock_num\tpar_num\tline_num\tword_num\tleft\ttop\twidth\theight\tconf\ttext\n", 1, 300, 0)
+
+		assert.Nil(t, err)
+		assert.Equal(t, pdf2text.BboxPage{Number: 1, Words: []pdf2text.BboxWord{}}, page)
+	})
+
+	t.Run("Invalid content", func(t *testing.T) {
+		_, err := ocr.ParseTSV("5\t1\t1\t1\t1\t1\tabc\t300\t400\t100\t96\tWord\n", 1, 300, 0)
+		assert.Equal(t, "invalid tsv line 1: strconv.ParseFloat: parsing \"abc\": invalid syntax", err.Error())
+
+		_, err = ocr.ParseTSV(tsvContent, 1, 0, 0)
+		assert.Equal(t, "invalid resolution 0", err.Error())
+	})
+}
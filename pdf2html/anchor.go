@@ -0,0 +1,158 @@
+package pdf2html
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Text on a page that marks the start or the end of a table. All set conditions
+// have to match, an anchor without any condition never matches
+type PdfXmlTableAnchor struct {
+	Text      string          // text that should be contained in the anchor text
+	Pattern   *regexp.Regexp  // pattern that should match the trimmed anchor text, e.g. ^Date\s+Description
+	FontSpec  *PdfXmlFontSpec // font of the anchor text, only the set fields are compared
+	Offset    int             // added to the top position of the anchor text
+	Exclusive bool            // the table starts below the line of a start anchor instead of at it
+}
+
+// Area of the table on a page after the anchors are resolved
+type PdfXmlTableArea struct {
+	Page       int  // number of the page
+	From, To   int  // area the table content was extracted from
+	StartFound bool // the start anchor was found and replaced From
+	EndFound   bool // the end anchor was found and replaced To
+}
+
+// Text of a page that matches an anchor
+type PdfXmlAnchorMatch struct {
+	Page int        // number of the page
+	Text PdfXmlText // matching text
+}
+
+// Get the texts of all pages that match the anchor, page by page from top to bottom and left to right
+func (d PdfXmlData) FindAnchor(anchor PdfXmlTableAnchor) []PdfXmlAnchorMatch {
+	fonts := fontSpecsByID(d.Pages)
+
+	matches := []PdfXmlAnchorMatch{}
+	for _, page := range d.Pages {
+		number := 0
+		if page.PageNumber != nil {
+			number = *page.PageNumber
+		}
+
+		for _, text := range page.getPositionedTexts() {
+			if anchor.matches(text, fonts) {
+				matches = append(matches, PdfXmlAnchorMatch{Page: number, Text: text})
+			}
+		}
+	}
+
+	return matches
+}
+
+func (a PdfXmlTableAnchor) matches(text PdfXmlText, fonts map[int]PdfXmlFontSpec) bool {
+	if a.Text == "" && a.Pattern == nil && a.FontSpec == nil {
+		return false
+	}
+
+	content := strings.TrimSpace(text.Content())
+	if a.Text != "" && !strings.Contains(content, a.Text) {
+		return false
+	}
+	if a.Pattern != nil && !a.Pattern.MatchString(content) {
+		return false
+	}
+	if a.FontSpec != nil {
+		if text.Font == nil {
+			return false
+		}
+
+		fontSpec, ok := fonts[*text.Font]
+		if !ok {
+			fontSpec = PdfXmlFontSpec{ID: text.Font}
+		}
+		if !a.FontSpec.matches(fontSpec) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f PdfXmlFontSpec) matches(fontSpec PdfXmlFontSpec) bool {
+	return equalPointer(f.ID, fontSpec.ID) &&
+		equalPointer(f.Size, fontSpec.Size) &&
+		equalPointer(f.Family, fontSpec.Family) &&
+		equalPointer(f.Color, fontSpec.Color)
+}
+
+// Checks if the value matches the expected one, a missing expectation matches everything
+func equalPointer[T comparable](expected, value *T) bool {
+	if expected == nil {
+		return true
+	}
+
+	return value != nil && *expected == *value
+}
+
+// Get the font specifications by id
+func fontSpecsByID(pages []PdfXmlPage) map[int]PdfXmlFontSpec {
+	fonts := map[int]PdfXmlFontSpec{}
+	for _, page := range pages {
+		for _, fontSpec := range page.FontSpecs {
+			if fontSpec.ID != nil {
+				fonts[*fontSpec.ID] = fontSpec
+			}
+		}
+	}
+
+	return fonts
+}
+
+// Resolves the area of the table on the page. A start anchor replaces From with its top position,
+// an end anchor replaces To with the position above it. If an anchor is not found From or To is used
+func (p PdfXmlPage) resolveArea(option PdfXmlTableOption, fonts map[int]PdfXmlFontSpec) PdfXmlTableArea {
+	area := PdfXmlTableArea{
+		From: option.From,
+		To:   option.To,
+	}
+	if p.PageNumber != nil {
+		area.Page = *p.PageNumber
+	}
+
+	if option.Start == nil && option.End == nil {
+		return area
+	}
+
+	texts := p.getPositionedTexts()
+	if option.Start != nil {
+		for _, text := range texts {
+			if !option.Start.matches(text, fonts) {
+				continue
+			}
+
+			area.From = *text.Top + option.Start.Offset
+			if option.Start.Exclusive {
+				height := 0
+				if text.Height != nil {
+					height = *text.Height
+				}
+				area.From += max(height, option.AllowedHeightVariance) + 1
+			}
+			area.StartFound = true
+			break
+		}
+	}
+
+	if option.End != nil {
+		for _, text := range texts {
+			if *text.Top > area.From && option.End.matches(text, fonts) {
+				area.To = *text.Top - 1 + option.End.Offset
+				area.EndFound = true
+				break
+			}
+		}
+	}
+
+	return area
+}
@@ -0,0 +1,185 @@
+package pdf2html_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+// Statement with an address block of a variable number of lines above the table
+func anchorPage(number, addressLines int, fontSpecs ...pdf2html.PdfXmlFontSpec) pdf2html.PdfXmlPage {
+	texts := []pdf2html.PdfXmlText{}
+	top := 50
+	for i := 0; i < addressLines; i++ {
+		texts = append(texts, textHelperFn(top, 100, "Address line", withFont(0)))
+		top += 20
+	}
+
+	texts = append(texts,
+		textHelperFn(top, 100, "Date", withFont(0)), textHelperFn(top, 200, "Description", withFont(0)),
+		textHelperFn(top+20, 100, "01.01.", withFont(0)), textHelperFn(top+20, 200, "Salary", withFont(0)),
+		textHelperFn(top+40, 100, "02.01.", withFont(0)), textHelperFn(top+40, 200, "Total 100.00", withFont(1)),
+		textHelperFn(top+60, 100, "Total of the statement", withFont(0)),
+	)
+
+	return pdf2html.PdfXmlPage{PageNumber: pointerHelperFn(number), FontSpecs: fontSpecs, Texts: texts}
+}
+
+var anchorFontSpecs = []pdf2html.PdfXmlFontSpec{
+	{ID: pointerHelperFn(0), Size: pointerHelperFn(8), Family: pointerHelperFn("Arial")},
+	{ID: pointerHelperFn(1), Size: pointerHelperFn(12), Family: pointerHelperFn("Arial-Bold")},
+}
+
+func anchorOption() pdf2html.PdfXmlTableOption {
+	return pdf2html.PdfXmlTableOption{
+		From:                  0,
+		To:                    1000,
+		Columns:               2,
+		GetColumnFunc:         pdf2html.GetColumnCalculationWithVariance([]int{100, 200}, 10),
+		AllowedHeightVariance: 5,
+		Start:                 &pdf2html.PdfXmlTableAnchor{Pattern: regexp.MustCompile(`^Date$`), Exclusive: true},
+		End:                   &pdf2html.PdfXmlTableAnchor{Pattern: regexp.MustCompile(`^Total`)},
+	}
+}
+
+func TestTableAnchors(t *testing.T) {
+	t.Helper()
+
+	t.Run("Anchors follow the address block", func(t *testing.T) {
+		for _, addressLines := range []int{2, 5} {
+			page := anchorPage(1, addressLines, anchorFontSpecs...)
+
+			table, area := page.ExtractTableContentWithArea(anchorOption())
+			assert.Equal(t, [][]string{
+				{"01.01.", "Salary"},
+			}, tableKeys(table))
+
+			top := 50 + addressLines*20
+			assert.Equal(t, pdf2html.PdfXmlTableArea{
+				Page:       1,
+				From:       top + 13,
+				To:         top + 39,
+				StartFound: true,
+				EndFound:   true,
+			}, area)
+		}
+	})
+
+	t.Run("Inclusive start anchor", func(t *testing.T) {
+		option := anchorOption()
+		option.Start = &pdf2html.PdfXmlTableAnchor{Text: "Date"}
+
+		table := anchorPage(1, 2, anchorFontSpecs...).ExtractTableContent(option)
+		assert.Equal(t, [][]string{
+			{"Date", "Description"},
+			{"01.01.", "Salary"},
+		}, tableKeys(table))
+	})
+
+	t.Run("Font spec anchor", func(t *testing.T) {
+		option := anchorOption()
+		option.End = &pdf2html.PdfXmlTableAnchor{
+			Pattern:  regexp.MustCompile(`^Total`),
+			FontSpec: &pdf2html.PdfXmlFontSpec{Family: pointerHelperFn("Arial")},
+		}
+
+		table, area := anchorPage(1, 2, anchorFontSpecs...).ExtractTableContentWithArea(option)
+		assert.Equal(t, [][]string{
+			{"01.01.", "Salary"},
+			{"02.01.", "Total 100.00"},
+		}, tableKeys(table))
+		assert.Equal(t, 149, area.To)
+	})
+
+	t.Run("Font spec anchor by id without fontspecs on the page", func(t *testing.T) {
+		option := anchorOption()
+		option.End = &pdf2html.PdfXmlTableAnchor{FontSpec: &pdf2html.PdfXmlFontSpec{ID: pointerHelperFn(1)}}
+
+		_, area := anchorPage(1, 2).ExtractTableContentWithArea(option)
+		assert.True(t, area.EndFound)
+		assert.Equal(t, 129, area.To)
+
+		option.End = &pdf2html.PdfXmlTableAnchor{FontSpec: &pdf2html.PdfXmlFontSpec{Size: pointerHelperFn(12)}}
+		_, area = anchorPage(1, 2).ExtractTableContentWithArea(option)
+		assert.False(t, area.EndFound)
+		assert.Equal(t, 1000, area.To)
+	})
+
+	t.Run("Font specs of previous pages in multi-page tables", func(t *testing.T) {
+		option := anchorOption()
+		option.End = &pdf2html.PdfXmlTableAnchor{FontSpec: &pdf2html.PdfXmlFontSpec{Size: pointerHelperFn(12)}}
+
+		data := pdf2html.PdfXmlData{
+			Pages: []pdf2html.PdfXmlPage{
+				anchorPage(1, 2, anchorFontSpecs...),
+				anchorPage(2, 4),
+			},
+		}
+
+		table, areas := data.ExtractTableWithAreas(pdf2html.MultiPageTableOption{
+			PdfXmlTableOption:   option,
+			KeepRepeatedHeaders: true,
+			KeepRepeatedFooters: true,
+		})
+		assert.Equal(t, [][]string{
+			{"01.01.", "Salary"},
+			{"01.01.", "Salary"},
+		}, tableKeys(table))
+		assert.Equal(t, []pdf2html.PdfXmlTableArea{
+			{Page: 1, From: 103, To: 129, StartFound: true, EndFound: true},
+			{Page: 2, From: 143, To: 169, StartFound: true, EndFound: true},
+		}, areas)
+	})
+
+	t.Run("Anchors not found", func(t *testing.T) {
+		option := anchorOption()
+		option.Start = &pdf2html.PdfXmlTableAnchor{Pattern: regexp.MustCompile(`^Booking`)}
+		option.End = &pdf2html.PdfXmlTableAnchor{}
+		option.From = 100
+
+		table, area := anchorPage(1, 2, anchorFontSpecs...).ExtractTableContentWithArea(option)
+		assert.Equal(t, pdf2html.PdfXmlTableArea{Page: 1, From: 100, To: 1000}, area)
+		assert.Equal(t, 3, len(table))
+	})
+
+	t.Run("Anchors above the page", func(t *testing.T) {
+		option := anchorOption()
+		option.From = -100
+
+		page := pdf2html.PdfXmlPage{PageNumber: pointerHelperFn(1), Texts: []pdf2html.PdfXmlText{
+			textHelperFn(-10, 100, "Date"), textHelperFn(-10, 200, "Description"),
+			textHelperFn(10, 100, "01.01."), textHelperFn(10, 200, "Salary"),
+			textHelperFn(30, 100, "Total"),
+		}}
+
+		table, area := page.ExtractTableContentWithArea(option)
+		assert.Equal(t, [][]string{
+			{"01.01.", "Salary"},
+		}, tableKeys(table))
+		assert.Equal(t, pdf2html.PdfXmlTableArea{Page: 1, From: 3, To: 29, StartFound: true, EndFound: true}, area)
+	})
+}
+
+func TestFindAnchor(t *testing.T) {
+	data := pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{
+		anchorPage(1, 1, pdf2html.PdfXmlFontSpec{ID: pointerHelperFn(1), Size: pointerHelperFn(14)}),
+		anchorPage(2, 0),
+		{PageNumber: pointerHelperFn(3), Texts: []pdf2html.PdfXmlText{{Text: pointerHelperFn("Total without position")}}},
+	}}
+
+	matches := data.FindAnchor(pdf2html.PdfXmlTableAnchor{Pattern: regexp.MustCompile(`^Total`)})
+	assert.Len(t, matches, 4)
+	assert.Equal(t, 1, matches[0].Page)
+	assert.Equal(t, "Total 100.00", matches[0].Text.Content())
+	assert.Equal(t, "Total of the statement", matches[1].Text.Content())
+	assert.Equal(t, 2, matches[2].Page)
+
+	// The fontspec of the first page is used on the following pages
+	matches = data.FindAnchor(pdf2html.PdfXmlTableAnchor{FontSpec: &pdf2html.PdfXmlFontSpec{Size: pointerHelperFn(14)}})
+	assert.Len(t, matches, 2)
+	assert.Equal(t, 2, matches[1].Page)
+
+	assert.Empty(t, data.FindAnchor(pdf2html.PdfXmlTableAnchor{}))
+}
@@ -0,0 +1,173 @@
+package pdf2html
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type BoilerplateOptions struct {
+	MinRatio  float64 // share of the pages a text has to be found on (default 0.5)
+	Tolerance int     // maximum difference of the positions on the pages (default 5), negative for the same position
+	Margin    float64 // share of the page height at the top and the bottom that is checked (default 0.15), 1 checks the whole page
+}
+
+// Text repeated at the same position on several pages, e.g. a running head, a footer or a page number
+type PdfXmlBoilerplate struct {
+	Pattern string              // lower case content with single spaces and numbers replaced by #
+	Matches []PdfXmlAnchorMatch // texts of the pages, page by page
+}
+
+const (
+	defaultBoilerplateRatio     = 0.5
+	defaultBoilerplateTolerance = 5
+	defaultBoilerplateMargin    = 0.15
+)
+
+var boilerplateNumberRegexp = regexp.MustCompile(`[0-9]+`)
+
+type boilerplateText struct {
+	page     int // index of the page
+	text     int // index of the text
+	position int // top of texts in the upper half, distance of the top to the bottom of the page in the lower half
+}
+
+// Get the texts repeated at the same position on at least MinRatio of the pages, at least two.
+// Texts are compared without case, spaces and numbers, so page numbers like "Page 3 of 10" are
+// found. Positions in the lower half of a page are measured from the bottom, so footers are found
+// on pages of different heights. The result is ordered by the first page and position
+func (d PdfXmlData) DetectBoilerplate(options BoilerplateOptions) []PdfXmlBoilerplate {
+	if options.MinRatio == 0 {
+		options.MinRatio = defaultBoilerplateRatio
+	}
+	if options.Tolerance == 0 {
+		options.Tolerance = defaultBoilerplateTolerance
+	}
+	if options.Margin == 0 {
+		options.Margin = defaultBoilerplateMargin
+	}
+	options.Tolerance = max(options.Tolerance, 0)
+	minPages := max(2, int(math.Ceil(options.MinRatio*float64(len(d.Pages)))))
+
+	candidates := map[string][]boilerplateText{}
+	for p, page := range d.Pages {
+		for t, text := range page.Texts {
+			if text.Top == nil || text.Left == nil {
+				continue
+			}
+
+			pattern := boilerplatePattern(text.Content())
+			if pattern == "" {
+				continue
+			}
+
+			position := *text.Top
+			if page.Height != nil {
+				margin := int(options.Margin * float64(*page.Height))
+				if position > margin && position < *page.Height-margin {
+					continue
+				}
+				if position > *page.Height/2 {
+					position -= *page.Height
+				}
+			}
+
+			candidates[pattern] = append(candidates[pattern], boilerplateText{page: p, text: t, position: position})
+		}
+	}
+
+	result := []PdfXmlBoilerplate{}
+	for pattern, texts := range candidates {
+		sort.SliceStable(texts, func(i, j int) bool {
+			return texts[i].position < texts[j].position
+		})
+
+		// Texts are grouped if their position is within the tolerance of the first one of the group
+		for start := 0; start < len(texts); {
+			end := start + 1
+			pages := map[int]bool{texts[start].page: true}
+			for end < len(texts) && texts[end].position-texts[start].position <= options.Tolerance {
+				pages[texts[end].page] = true
+				end++
+			}
+
+			if len(pages) >= minPages {
+				result = append(result, d.newBoilerplate(pattern, texts[start:end]))
+			}
+			start = end
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].Matches[0], result[j].Matches[0]
+		if a.Page != b.Page {
+			return a.Page < b.Page
+		}
+		if *a.Text.Top != *b.Text.Top {
+			return *a.Text.Top < *b.Text.Top
+		}
+		return result[i].Pattern < result[j].Pattern
+	})
+
+	return result
+}
+
+func (d PdfXmlData) newBoilerplate(pattern string, texts []boilerplateText) PdfXmlBoilerplate {
+	texts = append([]boilerplateText{}, texts...)
+	sort.SliceStable(texts, func(i, j int) bool {
+		return texts[i].page < texts[j].page
+	})
+
+	boilerplate := PdfXmlBoilerplate{Pattern: pattern}
+	for _, text := range texts {
+		page := d.Pages[text.page]
+		number := text.page + 1
+		if page.PageNumber != nil {
+			number = *page.PageNumber
+		}
+		boilerplate.Matches = append(boilerplate.Matches, PdfXmlAnchorMatch{Page: number, Text: page.Texts[text.text]})
+	}
+
+	return boilerplate
+}
+
+func boilerplatePattern(content string) string {
+	return boilerplateNumberRegexp.ReplaceAllString(strings.ToLower(strings.Join(strings.Fields(content), " ")), "#")
+}
+
+// Get a copy of the data without the boilerplate texts and the removed boilerplate, see DetectBoilerplate
+func (d PdfXmlData) RemoveBoilerplate(options BoilerplateOptions) (PdfXmlData, []PdfXmlBoilerplate) {
+	boilerplate := d.DetectBoilerplate(options)
+
+	removed := map[int]map[textKey]bool{}
+	for _, entry := range boilerplate {
+		for _, match := range entry.Matches {
+			if removed[match.Page] == nil {
+				removed[match.Page] = map[textKey]bool{}
+			}
+			removed[match.Page][newTextKey(match.Text)] = true
+		}
+	}
+
+	result := d
+	result.Pages = make([]PdfXmlPage, len(d.Pages))
+	for i, page := range d.Pages {
+		number := i + 1
+		if page.PageNumber != nil {
+			number = *page.PageNumber
+		}
+
+		texts := []PdfXmlText{}
+		for _, text := range page.Texts {
+			if !removed[number][newTextKey(text)] {
+				texts = append(texts, text)
+			}
+		}
+
+		page.Texts = texts
+		result.Pages[i] = page
+	}
+
+	return result, boilerplate
+}
@@ -0,0 +1,71 @@
+package pdf2html_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+// Pages with a running head, a footer with the page number, a notice on some pages and a table
+// with the same values at the same position on every page
+func boilerplateData() pdf2html.PdfXmlData {
+	data := pdf2html.PdfXmlData{}
+	for i := 1; i <= 4; i++ {
+		height := 1000 + i // footers are measured from the bottom
+		texts := []pdf2html.PdfXmlText{
+			textHelperFn(20+i%2, 50, "ACME  Annual Report", withWidth(200)),
+			textHelperFn(200, 50, "Amount", withWidth(200)),
+			textHelperFn(200+20*i, 50, fmt.Sprintf("Content of page %d", i), withWidth(200)),
+			textHelperFn(height-40, 400, fmt.Sprintf("Page %d of 4", i), withWidth(80)),
+			{Text: pointerHelperFn("no position")},
+		}
+		if i <= 2 {
+			texts = append(texts, textHelperFn(height-60, 50, "Confidential", withWidth(300)))
+		}
+
+		data.Pages = append(data.Pages, pdf2html.PdfXmlPage{PageNumber: pointerHelperFn(i), Height: pointerHelperFn(height), Texts: texts})
+	}
+
+	return data
+}
+
+func TestDetectBoilerplate(t *testing.T) {
+	data := boilerplateData()
+
+	patterns := func(boilerplate []pdf2html.PdfXmlBoilerplate) []string {
+		result := []string{}
+		for _, entry := range boilerplate {
+			result = append(result, entry.Pattern)
+		}
+		return result
+	}
+
+	boilerplate := data.DetectBoilerplate(pdf2html.BoilerplateOptions{})
+	assert.Equal(t, []string{"acme annual report", "confidential", "page # of #"}, patterns(boilerplate))
+	assert.Len(t, boilerplate[0].Matches, 4)
+	assert.Equal(t, 3, boilerplate[2].Matches[2].Page)
+	assert.Equal(t, "Page 3 of 4", boilerplate[2].Matches[2].Text.Content())
+
+	// The notice is only on half of the pages, the table header only outside of the margins
+	assert.Equal(t, []string{"acme annual report", "page # of #"}, patterns(data.DetectBoilerplate(pdf2html.BoilerplateOptions{MinRatio: 0.75})))
+	assert.Equal(t, []string{"acme annual report", "amount", "confidential", "page # of #"}, patterns(data.DetectBoilerplate(pdf2html.BoilerplateOptions{Margin: 1})))
+
+	// The running head differs by one pixel between the pages
+	assert.Equal(t, []string{"page # of #"}, patterns(data.DetectBoilerplate(pdf2html.BoilerplateOptions{MinRatio: 0.75, Tolerance: -1})))
+
+	assert.Empty(t, pdf2html.PdfXmlData{Pages: data.Pages[:1]}.DetectBoilerplate(pdf2html.BoilerplateOptions{}))
+}
+
+func TestRemoveBoilerplate(t *testing.T) {
+	data := boilerplateData()
+
+	cleaned, boilerplate := data.RemoveBoilerplate(pdf2html.BoilerplateOptions{})
+	assert.Len(t, boilerplate, 3)
+	assert.Equal(t, []string{"Amount", "Content of page 1", "no position"}, textContents(cleaned.Pages[0].Texts))
+	assert.Equal(t, []string{"Amount", "Content of page 4", "no position"}, textContents(cleaned.Pages[3].Texts))
+
+	// The data itself is not changed
+	assert.Len(t, data.Pages[0].Texts, 6)
+}
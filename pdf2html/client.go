@@ -2,9 +2,12 @@ package pdf2html
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"regexp"
 	"strconv"
@@ -92,7 +95,18 @@ func (c Client) checkVersion() error {
 // Execute function. Some outputs are using the stdin, some the stderr.
 // Therefore the three return values are representating stdout, stderr, error
 func (c Client) exec(args ...string) (*string, *string, error) {
-	cmd := tools.GetExecInstance().Command(client_cli, args...)
+	return c.execContext(context.Background(), args...)
+}
+
+// Execute function, the process is killed when the context is done
+func (c Client) execContext(ctx context.Context, args ...string) (*string, *string, error) {
+	command := tools.GetExecInstance().Command(client_cli, args...)
+
+	// The exec instance has no context, so the command is created again with the context
+	cmd := exec.CommandContext(ctx, command.Path, command.Args[1:]...)
+	cmd.Args = command.Args
+	cmd.Env = command.Env
+	cmd.Dir = command.Dir
 
 	var outBuffer bytes.Buffer
 	var errBuffer bytes.Buffer
@@ -103,6 +117,9 @@ func (c Client) exec(args ...string) (*string, *string, error) {
 
 	err := wrappedCmd.Run()
 
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -143,7 +160,26 @@ func cleanup(output *Output) error {
 	return nil
 }
 
+// Opening of files, which tools.OsInterface is missing. An os instance implementing it, e.g. a mock
+// of the tests, is used instead of the os package
+type FileOpener interface {
+	Open(name string) (io.ReadCloser, error)
+}
+
+func openFile(name string) (io.ReadCloser, error) {
+	if opener, ok := tools.GetOsInstance().(FileOpener); ok {
+		return opener.Open(name)
+	}
+
+	return os.Open(name)
+}
+
 func (c Client) GetXML(filePath string, options Options) (*PdfXmlData, error) {
+	return c.GetXMLContext(context.Background(), filePath, options)
+}
+
+// Get the XML data, pdftohtml is killed when the context is done
+func (c Client) GetXMLContext(ctx context.Context, filePath string, options Options) (*PdfXmlData, error) {
 	dir, err := tools.GetOsInstance().MkdirTemp(tools.GetOsInstance().TempDir(), fmt.Sprintf("%s-*", strings.ReplaceAll(filePath, "/", "_")))
 
 	if err != nil {
@@ -156,7 +192,7 @@ func (c Client) GetXML(filePath string, options Options) (*PdfXmlData, error) {
 	}()
 
 	options.Xml = true
-	output, err := c.Get(filePath, dir, options)
+	output, err := c.GetContext(ctx, filePath, dir, options)
 
 	if err != nil {
 		return nil, err
@@ -182,7 +218,88 @@ func (c Client) GetXML(filePath string, options Options) (*PdfXmlData, error) {
 	return &data, nil
 }
 
+// Get the XML pages one at a time instead of loading the whole document into
+// memory. Cancelling the context kills pdftohtml and stops the decoding between the pages
+func (c Client) StreamXML(ctx context.Context, filePath string, options Options, fn func(page PdfXmlPage) error) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	dir, err := tools.GetOsInstance().MkdirTemp(tools.GetOsInstance().TempDir(), fmt.Sprintf("%s-*", strings.ReplaceAll(filePath, "/", "_")))
+
+	if err != nil {
+		return err
+	}
+
+	// Delete the temp directory at the end
+	defer func() {
+		tools.GetOsInstance().RemoveAll(dir)
+	}()
+
+	options.Xml = true
+	output, err := c.GetContext(ctx, filePath, dir, options)
+
+	if err != nil {
+		return err
+	}
+
+	file, err := openFile(output.XmlFile)
+	if err != nil {
+		return err
+	}
+
+	err = DecodeXMLPages(ctx, file, fn)
+	file.Close()
+
+	if err != nil {
+		cleanup(output)
+		return err
+	}
+
+	return cleanup(output)
+}
+
+// Get the XML data serialized with the versioned JSON schema
+func (c Client) GetJSON(filePath string, options Options) (*string, error) {
+	data, err := c.GetXML(filePath, options)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	contentString := string(content)
+
+	return &contentString, nil
+}
+
+// Get the Markdown of a PDF, see PdfXmlData.ToMarkdown
+func (c Client) GetMarkdown(filePath string, options Options) (*string, error) {
+	return c.GetMarkdownWithOptions(filePath, options, MarkdownOptions{})
+}
+
+// Get the Markdown of a PDF with the tables and page breaks of the Markdown options
+func (c Client) GetMarkdownWithOptions(filePath string, options Options, markdownOptions MarkdownOptions) (*string, error) {
+	data, err := c.GetXML(filePath, options)
+	if err != nil {
+		return nil, err
+	}
+
+	content := data.ToMarkdown(markdownOptions)
+
+	return &content, nil
+}
+
 func (c Client) GetHTML(filePath string, options Options) (*string, error) {
+	return c.GetHTMLContext(context.Background(), filePath, options)
+}
+
+// Get the HTML, pdftohtml is killed when the context is done
+func (c Client) GetHTMLContext(ctx context.Context, filePath string, options Options) (*string, error) {
 	dir, err := tools.GetOsInstance().MkdirTemp(tools.GetOsInstance().TempDir(), fmt.Sprintf("%s-*", strings.ReplaceAll(filePath, "/", "_")))
 
 	if err != nil {
@@ -195,7 +312,7 @@ func (c Client) GetHTML(filePath string, options Options) (*string, error) {
 	}()
 
 	options.Xml = false
-	output, err := c.Get(filePath, dir, options)
+	output, err := c.GetContext(ctx, filePath, dir, options)
 
 	if err != nil {
 		return nil, err
@@ -218,6 +335,11 @@ func (c Client) GetHTML(filePath string, options Options) (*string, error) {
 
 // Get the content for a given file with options
 func (c Client) Get(filePath, outputPathPrefix string, options Options) (*Output, error) {
+	return c.GetContext(context.Background(), filePath, outputPathPrefix, options)
+}
+
+// Get the content for a given file with options, pdftohtml is killed when the context is done
+func (c Client) GetContext(ctx context.Context, filePath, outputPathPrefix string, options Options) (*Output, error) {
 	args := []string{}
 	if options.FirstPage != nil {
 		args = append(args, "-f", strconv.Itoa(*options.FirstPage))
@@ -287,7 +409,7 @@ func (c Client) Get(filePath, outputPathPrefix string, options Options) (*Output
 	xmlPath := fmt.Sprintf("%s.xml", outputPathPrefix)
 	args = append(args, filePath, outputPathPrefix)
 
-	out, e, err := c.exec(args...)
+	out, e, err := c.execContext(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -295,6 +417,11 @@ func (c Client) Get(filePath, outputPathPrefix string, options Options) (*Output
 		return nil, fmt.Errorf("channel: %s", *e)
 	}
 
+	// pdftohtml prints nothing with -q
+	if out == nil {
+		out = new(string)
+	}
+
 	return &Output{
 		Out:      *out,
 		HtmlFile: htmlPath,
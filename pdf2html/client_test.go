@@ -1,12 +1,17 @@
 package pdf2html_test
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"io/fs"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	gomock "github.com/nextunit-io/go-mock"
 	"github.com/nextunit-io/go-pdf2X/pdf2html"
@@ -101,8 +106,10 @@ var expectedXMLObj = pdf2html.PdfXmlData{
 					Left:     pointerHelperFn(106),
 					Width:    pointerHelperFn(227),
 					Height:   pointerHelperFn(19),
+					Font:     pointerHelperFn(0),
 					Text:     pointerHelperFn(""),
 					BoldText: pointerHelperFn("Test Bold"),
+					Runs:     []pdf2html.PdfXmlRun{{Text: "Test Bold", Bold: true}},
 				},
 				{
 					XMLName: xml.Name{
@@ -112,8 +119,10 @@ var expectedXMLObj = pdf2html.PdfXmlData{
 					Left:     pointerHelperFn(106),
 					Width:    pointerHelperFn(188),
 					Height:   pointerHelperFn(19),
+					Font:     pointerHelperFn(0),
 					Text:     pointerHelperFn(" mixed"),
 					BoldText: pointerHelperFn("Test"),
+					Runs:     []pdf2html.PdfXmlRun{{Text: "Test", Bold: true}, {Text: " mixed"}},
 				},
 				{
 					XMLName: xml.Name{
@@ -123,8 +132,10 @@ var expectedXMLObj = pdf2html.PdfXmlData{
 					Left:     pointerHelperFn(106),
 					Width:    pointerHelperFn(61),
 					Height:   pointerHelperFn(19),
+					Font:     pointerHelperFn(0),
 					Text:     pointerHelperFn("Only text"),
 					BoldText: nil,
+					Runs:     []pdf2html.PdfXmlRun{{Text: "Only text"}},
 				},
 			},
 		},
@@ -148,8 +159,10 @@ var expectedXMLObj = pdf2html.PdfXmlData{
 					Left:     pointerHelperFn(106),
 					Width:    pointerHelperFn(227),
 					Height:   pointerHelperFn(19),
+					Font:     pointerHelperFn(0),
 					Text:     pointerHelperFn(" p2"),
 					BoldText: pointerHelperFn("Test Bold"),
+					Runs:     []pdf2html.PdfXmlRun{{Text: "Test Bold", Bold: true}, {Text: " p2"}},
 				},
 				{
 					XMLName: xml.Name{
@@ -159,8 +172,10 @@ var expectedXMLObj = pdf2html.PdfXmlData{
 					Left:     pointerHelperFn(106),
 					Width:    pointerHelperFn(188),
 					Height:   pointerHelperFn(19),
+					Font:     pointerHelperFn(0),
 					Text:     pointerHelperFn(" mixed p2"),
 					BoldText: pointerHelperFn("Test"),
+					Runs:     []pdf2html.PdfXmlRun{{Text: "Test", Bold: true}, {Text: " mixed p2"}},
 				},
 				{
 					XMLName: xml.Name{
@@ -170,8 +185,10 @@ var expectedXMLObj = pdf2html.PdfXmlData{
 					Left:     pointerHelperFn(106),
 					Width:    pointerHelperFn(61),
 					Height:   pointerHelperFn(19),
+					Font:     pointerHelperFn(0),
 					Text:     pointerHelperFn("Only text p2"),
 					BoldText: nil,
+					Runs:     []pdf2html.PdfXmlRun{{Text: "Only text p2"}},
 				},
 			},
 		},
@@ -206,6 +223,69 @@ func pointerHelperFn[T any](x T) *T {
 	return &x
 }
 
+// Option of the text of textHelperFn
+type textOption func(text *pdf2html.PdfXmlText)
+
+func withWidth(width int) textOption {
+	return func(text *pdf2html.PdfXmlText) { text.Width = pointerHelperFn(width) }
+}
+
+func withHeight(height int) textOption {
+	return func(text *pdf2html.PdfXmlText) { text.Height = pointerHelperFn(height) }
+}
+
+func withFont(font int) textOption {
+	return func(text *pdf2html.PdfXmlText) { text.Font = pointerHelperFn(font) }
+}
+
+// Moves the content to the bold text
+func withBold() textOption {
+	return func(text *pdf2html.PdfXmlText) { text.BoldText, text.Text = text.Text, pointerHelperFn("") }
+}
+
+// Get a text at the position with the content, 50 wide and 12 high if the options do not set the size
+func textHelperFn(top, left int, content string, options ...textOption) pdf2html.PdfXmlText {
+	text := pdf2html.PdfXmlText{
+		Top:    pointerHelperFn(top),
+		Left:   pointerHelperFn(left),
+		Width:  pointerHelperFn(50),
+		Height: pointerHelperFn(12),
+		Text:   pointerHelperFn(content),
+	}
+	for _, option := range options {
+		option(&text)
+	}
+
+	return text
+}
+
+// Os mock that opens files, see pdf2html.FileOpener
+type fileOsMock struct {
+	*toolsmock.OsMock
+	open *gomock.ToolMock[struct{ Name string }, io.ReadCloser]
+}
+
+func (m fileOsMock) Open(name string) (io.ReadCloser, error) {
+	m.open.AddInput(struct{ Name string }{Name: name})
+
+	result, err := m.open.GetNextResult()
+	if err != nil {
+		return nil, err
+	}
+
+	return *result, nil
+}
+
+func setupFileOsMock() fileOsMock {
+	mock := fileOsMock{
+		OsMock: osMock,
+		open:   gomock.GetMock[struct{ Name string }, io.ReadCloser](fmt.Errorf("Open general error")),
+	}
+	tools.SetOsInstance(mock)
+
+	return mock
+}
+
 func setupTests() {
 	execMock = toolsmock.GetExecMock()
 	tools.SetExecInstance(execMock)
@@ -444,6 +524,21 @@ func TestGet(t *testing.T) {
 		})
 	}
 
+	t.Run("Check for get without output", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return nil, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		o, err := client.Get("filename", "test-output-path", pdf2html.Options{Quite: true})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "", o.Out)
+		assert.Equal(t, []string{"pdftohtml", "-q", "filename", "test-output-path"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
 	t.Run("Check for all flags", func(t *testing.T) {
 		runMock.Reset()
 
@@ -763,6 +858,240 @@ func TestGetXml(t *testing.T) {
 	})
 }
 
+func TestStreamXML(t *testing.T) {
+	t.Helper()
+
+	t.Run("Check for successful StreamXML", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		dir := filepath.Join(t.TempDir(), "output")
+		osMock.Mock.MkdirTemp.SetAlwaysReturn(dir)
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, os.WriteFile(fmt.Sprintf("%s.xml", dir), []byte(xmlContent), 0o600)
+		}
+
+		runMock.AddReturnValue(&fn)
+
+		pages := []pdf2html.PdfXmlPage{}
+		err := client.StreamXML(context.Background(), "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			pages = append(pages, page)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedXMLObj.Pages, pages)
+		assert.Equal(t, []string{"pdftohtml", "-xml", "filename", dir}, wrapperFnMock.GetLastInput().Cmd.Args)
+
+		assert.Equal(t, 0, osMock.Mock.ReadFile.HasBeenCalled())
+		assert.Equal(t, 2, osMock.Mock.Remove.HasBeenCalled())
+		assert.Equal(t, fmt.Sprintf("%s.xml", dir), osMock.Mock.Remove.GetInput(0).Name)
+		assert.Equal(t, 1, osMock.Mock.RemoveAll.HasBeenCalled())
+		assert.Equal(t, dir, osMock.Mock.RemoveAll.GetInput(0).Path)
+	})
+
+	t.Run("Callback fails", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		dir := filepath.Join(t.TempDir(), "output")
+		osMock.Mock.MkdirTemp.SetAlwaysReturn(dir)
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, os.WriteFile(fmt.Sprintf("%s.xml", dir), []byte(xmlContent), 0o600)
+		}
+
+		runMock.AddReturnValue(&fn)
+		err := client.StreamXML(context.Background(), "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			return fmt.Errorf("callback error")
+		})
+
+		assert.Equal(t, "callback error", err.Error())
+		assert.Equal(t, 2, osMock.Mock.Remove.HasBeenCalled())
+		assert.Equal(t, 1, osMock.Mock.RemoveAll.HasBeenCalled())
+	})
+
+	t.Run("Missing XML file", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		osMock.Mock.MkdirTemp.SetAlwaysReturn(filepath.Join(t.TempDir(), "output"))
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		err := client.StreamXML(context.Background(), "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			return nil
+		})
+
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+		assert.Equal(t, 1, osMock.Mock.RemoveAll.HasBeenCalled())
+	})
+
+	t.Run("Open with the os instance", func(t *testing.T) {
+		setupXmlTests()
+		fileMock := setupFileOsMock()
+		client, _ := pdf2html.NewClient()
+
+		osMock.Mock.MkdirTemp.SetAlwaysReturn("output")
+		fileMock.open.AddReturnValue(pointerHelperFn(io.NopCloser(strings.NewReader(xmlContent))))
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+
+		pages := []pdf2html.PdfXmlPage{}
+		err := client.StreamXML(context.Background(), "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			pages = append(pages, page)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedXMLObj.Pages, pages)
+		assert.Equal(t, 1, fileMock.open.HasBeenCalled())
+		assert.Equal(t, "output.xml", fileMock.open.GetInput(0).Name)
+		assert.Equal(t, 1, osMock.Mock.RemoveAll.HasBeenCalled())
+	})
+
+	t.Run("Error on open", func(t *testing.T) {
+		setupXmlTests()
+		fileMock := setupFileOsMock()
+		client, _ := pdf2html.NewClient()
+
+		osMock.Mock.MkdirTemp.SetAlwaysReturn("output")
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		err := client.StreamXML(context.Background(), "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			return nil
+		})
+
+		assert.Equal(t, "Open general error", err.Error())
+		assert.Equal(t, 1, fileMock.open.HasBeenCalled())
+		assert.Equal(t, 1, osMock.Mock.RemoveAll.HasBeenCalled())
+		assert.Equal(t, "output", osMock.Mock.RemoveAll.GetInput(0).Path)
+	})
+
+	t.Run("Get fails", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return nil, nil, fmt.Errorf("GET error")
+		}
+
+		runMock.AddReturnValue(&fn)
+		err := client.StreamXML(context.Background(), "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			return nil
+		})
+
+		assert.Equal(t, "GET error", err.Error())
+		assert.Equal(t, 1, osMock.Mock.RemoveAll.HasBeenCalled())
+	})
+
+	t.Run("Error on tmp dir", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		osMock.Mock.MkdirTemp.Reset()
+
+		err := client.StreamXML(context.Background(), "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			return nil
+		})
+
+		assert.Equal(t, "MkdirTemp general error", err.Error())
+		assert.Equal(t, 0, osMock.Mock.RemoveAll.HasBeenCalled())
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := client.StreamXML(ctx, "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			return nil
+		})
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 0, osMock.Mock.MkdirTemp.HasBeenCalled())
+	})
+
+	t.Run("Context cancelled while pdftohtml runs", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		// Run a long running process instead of pdftohtml
+		execMock.Mock.Command.SetAlwaysReturnFn(func() (**exec.Cmd, error) {
+			cmd := exec.Command("sleep", "10")
+			return &cmd, nil
+		})
+		wrapperFnMock.SetAlwaysReturnFn(func() (*pdf2html.CmdWrapper, error) {
+			var wrapper pdf2html.CmdWrapper = wrapperFnMock.GetLastInput().Cmd
+			return &wrapper, nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := client.StreamXML(ctx, "filename", pdf2html.Options{}, func(page pdf2html.PdfXmlPage) error {
+			return nil
+		})
+
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, 1, osMock.Mock.RemoveAll.HasBeenCalled())
+	})
+}
+
+func TestGetJSON(t *testing.T) {
+	t.Helper()
+
+	t.Run("Check for successful GetJSON", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		o, err := client.GetJSON("filename", pdf2html.Options{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"pdftohtml", "-xml", "filename", "test-mkdir-tmpdir"}, wrapperFnMock.GetLastInput().Cmd.Args)
+		assert.NotContains(t, *o, "XMLName")
+		assert.Contains(t, *o, `"schemaVersion":1`)
+
+		data, err := pdf2html.ParseJSON([]byte(*o))
+		assert.Nil(t, err)
+		assert.Equal(t, withoutXMLNames(expectedXMLObj), *data)
+	})
+
+	t.Run("Get fails", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return nil, nil, fmt.Errorf("GET error")
+		}
+
+		runMock.AddReturnValue(&fn)
+		o, err := client.GetJSON("filename", pdf2html.Options{})
+		assert.Equal(t, "GET error", err.Error())
+		assert.Nil(t, o)
+	})
+}
+
 func TestGetHTML(t *testing.T) {
 	t.Helper()
 
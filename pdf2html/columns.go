@@ -0,0 +1,267 @@
+package pdf2html
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Edge of the texts a column is aligned on
+type ColumnAlignment int
+
+const (
+	AlignLeft ColumnAlignment = iota
+	AlignRight
+	AlignCenter
+)
+
+func (a ColumnAlignment) String() string {
+	switch a {
+	case AlignLeft:
+		return "left"
+	case AlignRight:
+		return "right"
+	case AlignCenter:
+		return "center"
+	}
+
+	return fmt.Sprintf("ColumnAlignment(%d)", int(a))
+}
+
+type DetectOptions struct {
+	Tolerance             int     // maximum distance of edges in the same column (default 10)
+	MinSupport            float64 // share of the lines that need a text in a column (default 0.25)
+	AllowedHeightVariance int     // Define what variance is allowed to be in the same line (default 5)
+}
+
+type DetectedColumn struct {
+	Left, Right int             // horizontal extent of the texts in the column
+	Position    int             // average position of the aligned edge
+	Alignment   ColumnAlignment // edge the texts of the column are aligned on
+	Support     int             // number of lines with a text in the column
+}
+
+type DetectedColumns struct {
+	Columns       []DetectedColumn                   // detected columns from left to right
+	Count         int                                // number of columns, usable as PdfXmlTableOption.Columns
+	GetColumnFunc func(text PdfXmlText) (int, error) // usable as PdfXmlTableOption.GetColumnFunc
+}
+
+type edgeCluster struct {
+	alignment ColumnAlignment
+	texts     []int // indexes of the texts with an edge in the cluster
+	sum       int
+}
+
+func (c edgeCluster) position() int {
+	return c.sum / len(c.texts)
+}
+
+// Detects the columns of a table between from and to. The left, right and center edges
+// of the texts are clustered, every cluster that is found in enough lines becomes a column
+func (p PdfXmlPage) DetectTableColumns(from, to int, options DetectOptions) (*DetectedColumns, error) {
+	if options.Tolerance <= 0 {
+		options.Tolerance = 10
+	}
+	if options.MinSupport <= 0 {
+		options.MinSupport = 0.25
+	}
+	if options.AllowedHeightVariance <= 0 {
+		options.AllowedHeightVariance = 5
+	}
+
+	texts := []PdfXmlText{}
+	for _, text := range p.getSortedTexts(from, to) {
+		if text.Left == nil {
+			continue
+		}
+		texts = append(texts, text)
+	}
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("no texts between %d and %d", from, to)
+	}
+
+	// Assign every text to a line like ExtractTableContent does
+	lines := make([]int, len(texts))
+	lineCount, lineTop := 0, *texts[0].Top
+	for i, text := range texts {
+		if *text.Top-lineTop > options.AllowedHeightVariance {
+			lineCount++
+			lineTop = *text.Top
+		}
+		lines[i] = lineCount
+	}
+	lineCount++
+
+	minSupport := int(float64(lineCount)*options.MinSupport + 0.5)
+	if minSupport < 2 {
+		minSupport = min(2, lineCount)
+	}
+
+	clusters := []edgeCluster{}
+	for _, alignment := range []ColumnAlignment{AlignLeft, AlignRight, AlignCenter} {
+		clusters = append(clusters, clusterEdges(texts, alignment, options.Tolerance)...)
+	}
+
+	// Accept the clusters with the most lines first, every text belongs to one column only
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return lineSupport(clusters[i].texts, lines, nil) > lineSupport(clusters[j].texts, lines, nil)
+	})
+
+	claimed := make([]bool, len(texts))
+	columns := []DetectedColumn{}
+	for _, cluster := range clusters {
+		support := lineSupport(cluster.texts, lines, claimed)
+		if support < minSupport {
+			continue
+		}
+
+		column := DetectedColumn{
+			Left:      maxInt,
+			Right:     0,
+			Position:  cluster.position(),
+			Alignment: cluster.alignment,
+			Support:   support,
+		}
+		for _, index := range cluster.texts {
+			if claimed[index] {
+				continue
+			}
+			claimed[index] = true
+
+			left, right := textEdges(texts[index])
+			column.Left = min(column.Left, left)
+			column.Right = max(column.Right, right)
+		}
+
+		columns = append(columns, column)
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("cannot detect columns")
+	}
+
+	columns = mergeOverlappingColumns(columns)
+
+	return &DetectedColumns{
+		Columns:       columns,
+		Count:         len(columns),
+		GetColumnFunc: getColumnCalculationForColumns(columns, options.Tolerance),
+	}, nil
+}
+
+func textEdges(text PdfXmlText) (int, int) {
+	width := 0
+	if text.Width != nil {
+		width = *text.Width
+	}
+
+	return *text.Left, *text.Left + width
+}
+
+func clusterEdges(texts []PdfXmlText, alignment ColumnAlignment, tolerance int) []edgeCluster {
+	type edge struct {
+		position, text int
+	}
+
+	edges := []edge{}
+	for i, text := range texts {
+		left, right := textEdges(text)
+		switch alignment {
+		case AlignLeft:
+			edges = append(edges, edge{left, i})
+		case AlignRight:
+			edges = append(edges, edge{right, i})
+		case AlignCenter:
+			edges = append(edges, edge{(left + right) / 2, i})
+		}
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].position < edges[j].position
+	})
+
+	// A cluster is never wider than the tolerance
+	clusters := []edgeCluster{}
+	start := 0
+	for i, e := range edges {
+		if len(clusters) == 0 || e.position-start > tolerance {
+			clusters = append(clusters, edgeCluster{alignment: alignment})
+			start = e.position
+		}
+
+		cluster := &clusters[len(clusters)-1]
+		cluster.texts = append(cluster.texts, edges[i].text)
+		cluster.sum += e.position
+	}
+
+	return clusters
+}
+
+// Number of distinct lines of the texts that are not claimed yet
+func lineSupport(texts []int, lines []int, claimed []bool) int {
+	seen := map[int]bool{}
+	for _, index := range texts {
+		if claimed != nil && claimed[index] {
+			continue
+		}
+		seen[lines[index]] = true
+	}
+
+	return len(seen)
+}
+
+func mergeOverlappingColumns(columns []DetectedColumn) []DetectedColumn {
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Left < columns[j].Left
+	})
+
+	merged := []DetectedColumn{columns[0]}
+	for _, column := range columns[1:] {
+		last := &merged[len(merged)-1]
+		if column.Left >= last.Right {
+			merged = append(merged, column)
+			continue
+		}
+
+		// Keep the alignment of the column found in more lines
+		if column.Support > last.Support {
+			last.Position = column.Position
+			last.Alignment = column.Alignment
+			last.Support = column.Support
+		}
+		last.Right = max(last.Right, column.Right)
+	}
+
+	return merged
+}
+
+// Provides a function that matches a text to the column it overlaps the most. The
+// boundaries between the columns are in the middle of the gaps between them
+func getColumnCalculationForColumns(columns []DetectedColumn, tolerance int) func(text PdfXmlText) (int, error) {
+	boundaries := []int{columns[0].Left - tolerance}
+	for i := 1; i < len(columns); i++ {
+		boundaries = append(boundaries, (columns[i-1].Right+columns[i].Left)/2)
+	}
+	boundaries = append(boundaries, columns[len(columns)-1].Right+tolerance)
+
+	return func(text PdfXmlText) (int, error) {
+		if text.Left == nil {
+			return -1, fmt.Errorf("cannot find correct column")
+		}
+
+		left, right := textEdges(text)
+		column, best := -1, -1
+		for i := range columns {
+			overlap := min(right, boundaries[i+1]) - max(left, boundaries[i])
+			if overlap > best && (overlap > 0 || (left >= boundaries[i] && left <= boundaries[i+1])) {
+				column, best = i, overlap
+			}
+		}
+
+		if column == -1 {
+			return -1, fmt.Errorf("cannot find correct column")
+		}
+
+		return column, nil
+	}
+}
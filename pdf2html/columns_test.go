@@ -0,0 +1,158 @@
+package pdf2html_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+// Statement with a left aligned date and description and a right aligned amount
+func statementPage(zoom float64) pdf2html.PdfXmlPage {
+	z := func(v int) int {
+		return int(float64(v) * zoom)
+	}
+
+	texts := []pdf2html.PdfXmlText{
+		textHelperFn(z(20), z(50), "Statement of account", withWidth(z(200)), withHeight(15)),
+		textHelperFn(z(80), z(50), "Date", withWidth(z(30)), withHeight(15)),
+		textHelperFn(z(80), z(150), "Description", withWidth(z(70)), withHeight(15)),
+		textHelperFn(z(80), z(505), "Amount", withWidth(z(45)), withHeight(15)),
+	}
+
+	rows := []struct {
+		description      string
+		descriptionWidth int
+		amountLeft       int
+		amountWidth      int
+	}{
+		{"Salary", 60, 490, 60},
+		{"Rent payment for the apartment", 250, 520, 31},
+		{"Groceries", 90, 510, 39},
+		{"Insurance", 90, 500, 52},
+		{"Transfer to savings account", 230, 495, 55},
+	}
+	for i, row := range rows {
+		top := 100 + i*20 + i%2
+		texts = append(texts,
+			textHelperFn(z(top), z(50), "01.01.2024", withWidth(z(60)), withHeight(15)),
+			textHelperFn(z(top+1), z(150), row.description, withWidth(z(row.descriptionWidth)), withHeight(15)),
+			textHelperFn(z(top), z(row.amountLeft), "1.00", withWidth(z(row.amountWidth)), withHeight(15)),
+		)
+	}
+
+	return pdf2html.PdfXmlPage{Texts: texts}
+}
+
+func TestDetectTableColumns(t *testing.T) {
+	t.Helper()
+
+	t.Run("Detect columns with different alignments", func(t *testing.T) {
+		page := statementPage(1)
+
+		detected, err := page.DetectTableColumns(70, 300, pdf2html.DetectOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, detected.Count)
+		assert.Equal(t, []pdf2html.DetectedColumn{
+			{Left: 50, Right: 110, Position: 50, Alignment: pdf2html.AlignLeft, Support: 6},
+			{Left: 150, Right: 400, Position: 150, Alignment: pdf2html.AlignLeft, Support: 6},
+			{Left: 490, Right: 552, Position: 550, Alignment: pdf2html.AlignRight, Support: 6},
+		}, detected.Columns)
+
+		table := page.ExtractTableContent(pdf2html.PdfXmlTableOption{
+			From:                  70,
+			To:                    300,
+			Columns:               detected.Count,
+			GetColumnFunc:         detected.GetColumnFunc,
+			AllowedHeightVariance: 5,
+		})
+
+		assert.Equal(t, 6, len(table))
+		assert.Equal(t, "Amount", *table[0].Content[2].Text)
+		assert.Equal(t, "01.01.2024", *table[2].Content[0].Text)
+		assert.Equal(t, "Rent payment for the apartment", *table[2].Content[1].Text)
+		assert.Equal(t, "1.00", *table[2].Content[2].Text)
+	})
+
+	t.Run("Detection follows the zoom", func(t *testing.T) {
+		page := statementPage(1.5)
+
+		detected, err := page.DetectTableColumns(105, 450, pdf2html.DetectOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, detected.Count)
+		assert.Equal(t, []pdf2html.ColumnAlignment{pdf2html.AlignLeft, pdf2html.AlignLeft, pdf2html.AlignRight}, []pdf2html.ColumnAlignment{
+			detected.Columns[0].Alignment,
+			detected.Columns[1].Alignment,
+			detected.Columns[2].Alignment,
+		})
+
+		column, err := detected.GetColumnFunc(textHelperFn(200, 760, "1.00", withWidth(50), withHeight(15)))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, column)
+	})
+
+	t.Run("Column func", func(t *testing.T) {
+		detected, err := statementPage(1).DetectTableColumns(70, 300, pdf2html.DetectOptions{})
+		assert.Nil(t, err)
+
+		// Centered text overlapping the description column the most
+		column, err := detected.GetColumnFunc(textHelperFn(0, 300, "centered", withWidth(150), withHeight(15)))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, column)
+
+		// Text without width
+		column, err = detected.GetColumnFunc(pdf2html.PdfXmlText{Top: pointerHelperFn(0), Left: pointerHelperFn(55)})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, column)
+
+		_, err = detected.GetColumnFunc(textHelperFn(0, 700, "outside", withWidth(20), withHeight(15)))
+		assert.Equal(t, "cannot find correct column", err.Error())
+
+		_, err = detected.GetColumnFunc(pdf2html.PdfXmlText{})
+		assert.Equal(t, "cannot find correct column", err.Error())
+	})
+
+	t.Run("Overlapping columns are merged", func(t *testing.T) {
+		page := pdf2html.PdfXmlPage{
+			Texts: []pdf2html.PdfXmlText{
+				textHelperFn(100, 50, "left aligned", withWidth(80), withHeight(15)),
+				textHelperFn(120, 50, "left aligned", withWidth(100), withHeight(15)),
+				textHelperFn(140, 50, "left aligned", withWidth(90), withHeight(15)),
+				textHelperFn(160, 120, "right aligned", withWidth(60), withHeight(15)),
+				textHelperFn(180, 120, "right aligned", withWidth(60), withHeight(15)),
+			},
+		}
+
+		detected, err := page.DetectTableColumns(0, 500, pdf2html.DetectOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, []pdf2html.DetectedColumn{
+			{Left: 50, Right: 180, Position: 50, Alignment: pdf2html.AlignLeft, Support: 3},
+		}, detected.Columns)
+	})
+
+	t.Run("No texts", func(t *testing.T) {
+		detected, err := statementPage(1).DetectTableColumns(1000, 2000, pdf2html.DetectOptions{})
+		assert.Nil(t, detected)
+		assert.Equal(t, "no texts between 1000 and 2000", err.Error())
+	})
+
+	t.Run("No columns", func(t *testing.T) {
+		page := pdf2html.PdfXmlPage{
+			Texts: []pdf2html.PdfXmlText{
+				textHelperFn(100, 50, "first", withWidth(100), withHeight(15)),
+				textHelperFn(120, 300, "second", withWidth(20), withHeight(15)),
+			},
+		}
+
+		detected, err := page.DetectTableColumns(0, 500, pdf2html.DetectOptions{})
+		assert.Nil(t, detected)
+		assert.Equal(t, "cannot detect columns", err.Error())
+	})
+
+	t.Run("Alignment names", func(t *testing.T) {
+		assert.Equal(t, "left", pdf2html.AlignLeft.String())
+		assert.Equal(t, "right", pdf2html.AlignRight.String())
+		assert.Equal(t, "center", pdf2html.AlignCenter.String())
+		assert.Equal(t, "ColumnAlignment(7)", pdf2html.ColumnAlignment(7).String())
+	})
+}
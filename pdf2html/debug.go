@@ -0,0 +1,214 @@
+package pdf2html
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Colours of the columns, repeated if there are more columns
+var debugColumnColors = []string{"#1f77b4", "#2ca02c", "#9467bd", "#8c564b", "#e377c2", "#17becf", "#bcbd22", "#7f7f7f"}
+
+const (
+	debugBandColor      = "#ffd54f" // area between From and To
+	debugRowColor       = "#0d47a1" // boxes of the extracted rows
+	debugUnmatchedColor = "#d62728" // texts in the area without a column
+	debugFilteredColor  = "#ff7f0e" // texts in the area whose row is not in the entries
+	debugOutsideColor   = "#9e9e9e" // texts outside of the area
+)
+
+// A range of positions that GetColumnFunc assigns to the same column
+type debugColumnRange struct {
+	column   int
+	from, to int
+}
+
+// Renders an SVG of the page to tune the table options. It shows every text box, the area between
+// From and To, the column ranges of GetColumnFunc and the boxes of the rows in entries. Texts of the
+// columns have the colour of their column, texts without a column are red, texts of rows that are
+// not in entries (e.g. dropped by FilterFunc) are orange and texts outside of the area are grey
+func RenderDebugSVG(page PdfXmlPage, option PdfXmlTableOption, entries []*PdfXmlTableEntry) []byte {
+	area := page.resolveArea(option, fontSpecsByID([]PdfXmlPage{page}))
+	width, height := debugPageSize(page)
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif">`+"\n", width, height, width, height)
+	fmt.Fprintf(&buffer, `<rect x="0" y="0" width="%d" height="%d" fill="#ffffff" stroke="#000000"/>`+"\n", width, height)
+
+	// Area of the table
+	from, to := max(area.From, 0), min(area.To, height)
+	if to >= from {
+		fmt.Fprintf(&buffer, `<rect class="area" x="0" y="%d" width="%d" height="%d" fill="%s" fill-opacity="0.15" stroke="%s" stroke-dasharray="6 3"/>`+"\n",
+			from, width, to-from, debugBandColor, debugBandColor)
+	}
+
+	// Column ranges of the GetColumnFunc
+	ranges := debugColumnRanges(option, width, from)
+	for _, r := range ranges {
+		color := debugColumnColors[r.column%len(debugColumnColors)]
+		fmt.Fprintf(&buffer, `<rect class="column" x="%d" y="%d" width="%d" height="%d" fill="%s" fill-opacity="0.1"/>`+"\n",
+			r.from, from, r.to-r.from+1, max(to-from, 0), color)
+		fmt.Fprintf(&buffer, `<text x="%d" y="%d" font-size="10" fill="%s">%d</text>`+"\n", r.from+2, from+10, color, r.column)
+	}
+
+	// Texts that are part of the entries
+	extracted := map[string]bool{}
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		for _, content := range entry.Content {
+			if content == nil {
+				continue
+			}
+			for _, line := range content.Lines {
+				extracted[debugTextKey(line)] = true
+			}
+		}
+	}
+
+	for _, text := range page.Texts {
+		if text.Top == nil || text.Left == nil {
+			continue
+		}
+
+		left, right := textEdges(text)
+		textHeight := 0
+		if text.Height != nil {
+			textHeight = *text.Height
+		}
+
+		class, color := "outside", debugOutsideColor
+		if *text.Top >= area.From && *text.Top <= area.To {
+			column := -1
+			if option.GetColumnFunc != nil {
+				if c, err := option.GetColumnFunc(text); err == nil {
+					column = c
+				}
+			}
+
+			switch {
+			case column < 0 || column >= option.Columns:
+				class, color = "unmatched", debugUnmatchedColor
+			case !extracted[debugTextKey(text)]:
+				class, color = "filtered", debugFilteredColor
+			default:
+				class, color = "text", debugColumnColors[column%len(debugColumnColors)]
+			}
+		}
+
+		fill := "none"
+		if class == "unmatched" || class == "filtered" {
+			fill = color
+		}
+		fmt.Fprintf(&buffer, `<rect class="%s" x="%d" y="%d" width="%d" height="%d" fill="%s" fill-opacity="0.3" stroke="%s"/>`+"\n",
+			class, left, *text.Top, right-left, textHeight, fill, color)
+		fmt.Fprintf(&buffer, `<text x="%d" y="%d" font-size="%d" fill="%s">%s</text>`+"\n",
+			left, *text.Top+textHeight-2, max(textHeight-4, 4), color, escapeXML(text.Content()))
+	}
+
+	// Boxes of the rows
+	for i, entry := range entries {
+		if entry == nil || entry.MinTop > entry.MaxTop {
+			continue
+		}
+
+		left, right, bottom := entry.MinLeft, entry.MaxLeft, entry.MaxTop
+		for _, content := range entry.Content {
+			if content == nil {
+				continue
+			}
+			for _, line := range content.Lines {
+				if line.Left == nil || line.Top == nil {
+					continue
+				}
+				_, lineRight := textEdges(line)
+				right = max(right, lineRight)
+				if line.Height != nil {
+					bottom = max(bottom, *line.Top+*line.Height)
+				}
+			}
+		}
+
+		fmt.Fprintf(&buffer, `<rect class="row" x="%d" y="%d" width="%d" height="%d" fill="none" stroke="%s" stroke-width="1.5"/>`+"\n",
+			left-2, entry.MinTop-2, right-left+4, bottom-entry.MinTop+4, debugRowColor)
+		fmt.Fprintf(&buffer, `<text x="%d" y="%d" font-size="9" fill="%s">%d</text>`+"\n", max(left-20, 0), entry.MinTop+9, debugRowColor, i)
+	}
+
+	buffer.WriteString("</svg>\n")
+
+	return buffer.Bytes()
+}
+
+// Get the size of the page, pages without a size are as large as their texts
+func debugPageSize(page PdfXmlPage) (int, int) {
+	width, height := 0, 0
+	if page.Width != nil {
+		width = *page.Width
+	}
+	if page.Height != nil {
+		height = *page.Height
+	}
+	if width > 0 && height > 0 {
+		return width, height
+	}
+
+	for _, text := range page.Texts {
+		if text.Top == nil || text.Left == nil {
+			continue
+		}
+
+		_, right := textEdges(text)
+		bottom := *text.Top
+		if text.Height != nil {
+			bottom += *text.Height
+		}
+		width = max(width, right+10)
+		height = max(height, bottom+10)
+	}
+
+	return width, height
+}
+
+// Probes the GetColumnFunc with an empty text at every position of the page and
+// combines the positions of the same column to ranges
+func debugColumnRanges(option PdfXmlTableOption, width, top int) []debugColumnRange {
+	if option.GetColumnFunc == nil {
+		return nil
+	}
+
+	ranges := []debugColumnRange{}
+	for x := 0; x < width; x++ {
+		column, err := option.GetColumnFunc(PdfXmlText{
+			Top:      &top,
+			Left:     &x,
+			Width:    new(int),
+			Height:   new(int),
+			Font:     new(int),
+			Text:     new(string),
+			BoldText: new(string),
+		})
+		if err != nil || column < 0 {
+			continue
+		}
+
+		if len(ranges) != 0 && ranges[len(ranges)-1].column == column && ranges[len(ranges)-1].to == x-1 {
+			ranges[len(ranges)-1].to = x
+			continue
+		}
+		ranges = append(ranges, debugColumnRange{column: column, from: x, to: x})
+	}
+
+	return ranges
+}
+
+func debugTextKey(text PdfXmlText) string {
+	top, left := 0, 0
+	if text.Top != nil {
+		top = *text.Top
+	}
+	if text.Left != nil {
+		left = *text.Left
+	}
+
+	return fmt.Sprintf("%d:%d:%s", top, left, text.Content())
+}
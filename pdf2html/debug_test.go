@@ -0,0 +1,107 @@
+package pdf2html_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDebugSVG(t *testing.T) {
+	page := pdf2html.PdfXmlPage{
+		PageNumber: pointerHelperFn(1),
+		Width:      pointerHelperFn(400),
+		Height:     pointerHelperFn(300),
+		Texts: []pdf2html.PdfXmlText{
+			textHelperFn(20, 100, "Statement", withWidth(40)),
+			textHelperFn(100, 100, "01.01.", withWidth(40)), textHelperFn(100, 200, "Salary", withWidth(40)),
+			textHelperFn(120, 100, "02.01.", withWidth(40)), textHelperFn(120, 200, "Rent", withWidth(40)), textHelperFn(120, 300, "<note>", withWidth(40)),
+			textHelperFn(140, 100, "Total", withWidth(40)), textHelperFn(140, 200, "100", withWidth(40)),
+		},
+	}
+
+	filter := func(entry pdf2html.PdfXmlTableEntry) bool {
+		return entry.Content[0] != nil && *entry.Content[0].Text != "Total"
+	}
+	option := pdf2html.PdfXmlTableOption{
+		From:                  90,
+		To:                    200,
+		Columns:               2,
+		GetColumnFunc:         pdf2html.GetColumnCalculationWithVariance([]int{100, 200}, 10),
+		AllowedHeightVariance: 5,
+		FilterFunc:            &filter,
+	}
+	entries := page.ExtractTableContent(option)
+	assert.Len(t, entries, 2)
+
+	svg := string(pdf2html.RenderDebugSVG(page, option, entries))
+
+	t.Run("valid xml", func(t *testing.T) {
+		decoder := xml.NewDecoder(bytes.NewReader([]byte(svg)))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			assert.Nil(t, err)
+			if err != nil {
+				break
+			}
+		}
+	})
+
+	t.Run("area and columns", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="400" height="300"`))
+		assert.Contains(t, svg, `<rect class="area" x="0" y="90" width="400" height="110"`)
+		assert.Contains(t, svg, `<rect class="column" x="95" y="90" width="11" height="110"`)
+		assert.Contains(t, svg, `<rect class="column" x="195" y="90" width="11" height="110"`)
+		assert.Equal(t, 2, strings.Count(svg, `class="column"`))
+	})
+
+	t.Run("texts", func(t *testing.T) {
+		assert.Equal(t, 1, strings.Count(svg, `class="outside"`))
+		assert.Equal(t, 1, strings.Count(svg, `class="unmatched"`))
+		assert.Equal(t, 2, strings.Count(svg, `class="filtered"`))
+		assert.Equal(t, 4, strings.Count(svg, `class="text"`))
+		assert.Contains(t, svg, `&lt;note&gt;`)
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		assert.Equal(t, 2, strings.Count(svg, `class="row"`))
+		assert.Contains(t, svg, `<rect class="row" x="98" y="98" width="144" height="16"`)
+	})
+
+	t.Run("page without size and options without column func", func(t *testing.T) {
+		page := pdf2html.PdfXmlPage{Texts: []pdf2html.PdfXmlText{textHelperFn(10, 10, "a", withWidth(40)), {Text: pointerHelperFn("no position")}}}
+		svg := string(pdf2html.RenderDebugSVG(page, pdf2html.PdfXmlTableOption{To: 100}, nil))
+		assert.Contains(t, svg, `width="60" height="32"`)
+		assert.Equal(t, 1, strings.Count(svg, `class="unmatched"`))
+		assert.NotContains(t, svg, `class="column"`)
+	})
+
+	t.Run("column func reading the content", func(t *testing.T) {
+		page := page
+		texts := []pdf2html.PdfXmlText{}
+		for _, text := range page.Texts {
+			text.Font = pointerHelperFn(1)
+			text.BoldText = pointerHelperFn("")
+			texts = append(texts, text)
+		}
+		page.Texts = texts
+
+		option := option
+		option.GetColumnFunc = func(text pdf2html.PdfXmlText) (int, error) {
+			if *text.Font != 0 || *text.Text+*text.BoldText != "" {
+				return -1, nil
+			}
+			return pdf2html.GetColumnCalculationWithVariance([]int{100, 200}, 10)(text)
+		}
+
+		svg := string(pdf2html.RenderDebugSVG(page, option, entries))
+		assert.Equal(t, 2, strings.Count(svg, `class="column"`))
+	})
+}
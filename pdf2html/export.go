@@ -0,0 +1,428 @@
+package pdf2html
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var xlsxNumberRegexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// How the bold text of a cell is combined with the normal text
+type BoldMode int
+
+const (
+	BoldCombined  BoldMode = iota // the full content in order, cells without runs write the bold text in front of the normal text
+	BoldOnly                      // only the bold text
+	BoldPlainOnly                 // only the text that is not bold
+	BoldMarked                    // the full content with the bold parts surrounded by BoldMarker
+)
+
+type TableExportOptions struct {
+	Header     []string // header row written in front of the entries, JSON objects use it as keys
+	HeaderRows int      // number of entries at the start that are header rows, JSON objects use the first one as keys
+	Bold       BoldMode // how the bold text is combined with the normal text
+	BoldMarker string   // marker around bold parts with BoldMarked (default "**")
+	Empty      string   // text of cells without content
+	Comma      rune     // separator of CSV (default ',')
+	JSONNull   bool     // write null for cells without content in JSON instead of Empty
+	SheetName  string   // name of the XLSX worksheet (default "Sheet1")
+	Numbers    bool     // write XLSX cells that are finite decimal numbers like -12.50 as numbers
+}
+
+// Get the text of a table cell as it is exported, nil if the cell has no content
+func (o TableExportOptions) CellText(content *PdfXmlTableEntryContent) *string {
+	if content == nil {
+		return nil
+	}
+
+	lines := content.Lines
+	if len(lines) == 0 {
+		lines = []PdfXmlText{{Text: content.Text, BoldText: content.BoldText, Runs: content.Runs}}
+	}
+
+	parts := []string{}
+	for _, line := range lines {
+		part := ""
+		switch o.Bold {
+		case BoldOnly:
+			part = lineText(line, true)
+		case BoldPlainOnly:
+			part = lineText(line, false)
+		case BoldMarked:
+			marker := o.BoldMarker
+			if marker == "" {
+				marker = "**"
+			}
+			part = markedText(line, marker)
+		default:
+			part = combinedText(line)
+		}
+
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	text := strings.Join(parts, " ")
+	return &text
+}
+
+func (o TableExportOptions) cell(content *PdfXmlTableEntryContent) string {
+	text := o.CellText(content)
+	if text == nil || *text == "" {
+		return o.Empty
+	}
+
+	return *text
+}
+
+// Get the rows of the table, starting with the header row
+func (o TableExportOptions) rows(entries []*PdfXmlTableEntry) [][]string {
+	rows := [][]string{}
+	if len(o.Header) != 0 {
+		rows = append(rows, o.Header)
+	}
+
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		row := []string{}
+		for _, content := range entry.Content {
+			row = append(row, o.cell(content))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// Get the trimmed text of a line with the bold text in front of the normal text if the line has no runs
+func combinedText(line PdfXmlText) string {
+	if len(line.Runs) != 0 {
+		return strings.TrimSpace(line.Content())
+	}
+
+	parts := []string{}
+	if line.BoldText != nil && strings.TrimSpace(*line.BoldText) != "" {
+		parts = append(parts, strings.TrimSpace(*line.BoldText))
+	}
+	if line.Text != nil && strings.TrimSpace(*line.Text) != "" {
+		parts = append(parts, strings.TrimSpace(*line.Text))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Get the trimmed bold or not bold text of a line
+func lineText(line PdfXmlText, bold bool) string {
+	if len(line.Runs) != 0 {
+		var builder strings.Builder
+		for _, run := range line.Runs {
+			if run.Bold == bold {
+				builder.WriteString(run.Text)
+			}
+		}
+		return strings.TrimSpace(builder.String())
+	}
+
+	if bold && line.BoldText != nil {
+		return strings.TrimSpace(*line.BoldText)
+	}
+	if !bold && line.Text != nil {
+		return strings.TrimSpace(*line.Text)
+	}
+
+	return ""
+}
+
+func markedText(line PdfXmlText, marker string) string {
+	runs := line.Runs
+	if len(runs) == 0 {
+		if line.BoldText != nil && strings.TrimSpace(*line.BoldText) != "" {
+			runs = append(runs, PdfXmlRun{Text: strings.TrimSpace(*line.BoldText) + " ", Bold: true})
+		}
+		if line.Text != nil {
+			runs = append(runs, PdfXmlRun{Text: strings.TrimSpace(*line.Text)})
+		}
+	}
+
+	var builder strings.Builder
+	for _, run := range runs {
+		if !run.Bold || strings.TrimSpace(run.Text) == "" {
+			builder.WriteString(run.Text)
+			continue
+		}
+
+		// Keep the spaces outside of the markers
+		trimmed := strings.TrimSpace(run.Text)
+		start := strings.Index(run.Text, trimmed)
+		builder.WriteString(run.Text[:start])
+		builder.WriteString(marker + trimmed + marker)
+		builder.WriteString(run.Text[start+len(trimmed):])
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+// Writes the table as CSV
+func WriteTableCSV(w io.Writer, entries []*PdfXmlTableEntry, options TableExportOptions) error {
+	writer := csv.NewWriter(w)
+	if options.Comma != 0 {
+		writer.Comma = options.Comma
+	}
+
+	err := writer.WriteAll(options.rows(entries))
+	if err != nil {
+		return fmt.Errorf("cannot write csv: %w", err)
+	}
+
+	return nil
+}
+
+// Writes the table as tab separated values
+func WriteTableTSV(w io.Writer, entries []*PdfXmlTableEntry, options TableExportOptions) error {
+	options.Comma = '\t'
+	return WriteTableCSV(w, entries, options)
+}
+
+// JSON object that keeps the order of its keys
+type jsonObject struct {
+	keys   []string
+	values []any
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		content, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(content)
+		buffer.WriteByte(':')
+
+		content, err = json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(content)
+	}
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// Get the unique keys of the JSON objects, cells without header are keyed by their index and
+// repeated headers get the suffix _2, _3, ...
+func jsonKeys(header []string, columns int) []string {
+	keys := []string{}
+	used := map[string]bool{}
+	for i := 0; i < max(columns, len(header)); i++ {
+		key := strconv.Itoa(i)
+		if i < len(header) && header[i] != "" {
+			key = header[i]
+		}
+
+		unique := key
+		for n := 2; used[unique]; n++ {
+			unique = fmt.Sprintf("%s_%d", key, n)
+		}
+		used[unique] = true
+		keys = append(keys, unique)
+	}
+
+	return keys
+}
+
+// Writes the table as JSON. With a header the rows are objects keyed by the header cells in the
+// order of the columns, otherwise arrays. Header rows in the entries are not written as rows
+func WriteTableJSON(w io.Writer, entries []*PdfXmlTableEntry, options TableExportOptions) error {
+	header := options.Header
+	if len(header) == 0 && options.HeaderRows > 0 && len(entries) != 0 && entries[0] != nil {
+		for _, content := range entries[0].Content {
+			header = append(header, options.cell(content))
+		}
+	}
+	entries = entries[min(options.HeaderRows, len(entries)):]
+
+	rows := []any{}
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		cells := []any{}
+		for _, content := range entry.Content {
+			if options.JSONNull && options.CellText(content) == nil {
+				cells = append(cells, nil)
+				continue
+			}
+			cells = append(cells, options.cell(content))
+		}
+
+		if len(header) == 0 {
+			rows = append(rows, cells)
+			continue
+		}
+
+		rows = append(rows, jsonObject{keys: jsonKeys(header, len(cells))[:len(cells)], values: cells})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(rows)
+	if err != nil {
+		return fmt.Errorf("cannot write json: %w", err)
+	}
+
+	return nil
+}
+
+// Check the rules of Excel for worksheet names: at most 31 characters, none of []:*?/\ and no
+// apostrophe at the start or the end
+func validateSheetName(name string) error {
+	if utf8.RuneCountInString(name) > 31 {
+		return fmt.Errorf("invalid sheet name %q: longer than 31 characters", name)
+	}
+	if strings.ContainsAny(name, `[]:*?/\`) {
+		return fmt.Errorf("invalid sheet name %q: contains one of []:*?/\\", name)
+	}
+	if strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
+		return fmt.Errorf("invalid sheet name %q: starts or ends with an apostrophe", name)
+	}
+
+	return nil
+}
+
+// Writes the table as Office Open XML workbook with a single worksheet, header rows are bold
+func WriteTableXLSX(w io.Writer, entries []*PdfXmlTableEntry, options TableExportOptions) error {
+	sheetName := options.SheetName
+	if sheetName == "" {
+		sheetName = "Sheet1"
+	}
+	err := validateSheetName(sheetName)
+	if err != nil {
+		return err
+	}
+
+	rows := options.rows(entries)
+	headerRows := options.HeaderRows
+	if len(options.Header) != 0 {
+		headerRows++
+	}
+
+	var sheet strings.Builder
+	sheet.WriteString(xml.Header)
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&sheet, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			reference := xlsxColumn(j) + strconv.Itoa(i+1)
+			style := ""
+			if i < headerRows {
+				style = ` s="1"`
+			}
+
+			if options.Numbers && i >= headerRows && xlsxNumber(cell) {
+				fmt.Fprintf(&sheet, `<c r="%s"%s><v>%s</v></c>`, reference, style, cell)
+				continue
+			}
+
+			fmt.Fprintf(&sheet, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, reference, style, escapeXML(cell))
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	files := []struct {
+		name, content string
+	}{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + escapeXML(sheetName) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+			`</Relationships>`},
+		{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+			`</styleSheet>`},
+		{"xl/worksheets/sheet1.xml", sheet.String()},
+	}
+
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("cannot write xlsx: %w", err)
+		}
+
+		_, err = io.WriteString(writer, file.content)
+		if err != nil {
+			return fmt.Errorf("cannot write xlsx: %w", err)
+		}
+	}
+
+	err = archive.Close()
+	if err != nil {
+		return fmt.Errorf("cannot write xlsx: %w", err)
+	}
+
+	return nil
+}
+
+// Check if the cell is a plain decimal number that is finite as float
+func xlsxNumber(cell string) bool {
+	if !xlsxNumberRegexp.MatchString(cell) {
+		return false
+	}
+
+	value, err := strconv.ParseFloat(cell, 64)
+	return err == nil && !math.IsInf(value, 0) && !math.IsNaN(value)
+}
+
+// Get the letters of a column, e.g. 0 is A and 27 is AB
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+
+	return name
+}
+
+func escapeXML(content string) string {
+	var builder strings.Builder
+	xml.EscapeText(&builder, []byte(content))
+	return builder.String()
+}
@@ -0,0 +1,203 @@
+package pdf2html_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+func exportEntries() []*pdf2html.PdfXmlTableEntry {
+	return []*pdf2html.PdfXmlTableEntry{
+		tableEntry(1, "Date", "Description", "Amount"),
+		{Content: []*pdf2html.PdfXmlTableEntryContent{
+			tableCell("01.02."),
+			{Runs: []pdf2html.PdfXmlRun{{Text: "Fee ", Bold: true}, {Text: "for \"March\""}}},
+			nil,
+		}},
+		{Content: []*pdf2html.PdfXmlTableEntryContent{
+			tableCell("02.02."),
+			{Text: pointerHelperFn(" Transfer"), BoldText: pointerHelperFn("Bank")},
+			tableCell("12.50"),
+		}},
+	}
+}
+
+func TestTableExportCellText(t *testing.T) {
+	cell := &pdf2html.PdfXmlTableEntryContent{Runs: []pdf2html.PdfXmlRun{{Text: "Fee ", Bold: true}, {Text: "for March"}}}
+	withoutRuns := &pdf2html.PdfXmlTableEntryContent{Text: pointerHelperFn(" Transfer"), BoldText: pointerHelperFn("Bank")}
+
+	for _, test := range []struct {
+		name                string
+		options             pdf2html.TableExportOptions
+		expected            string
+		expectedWithoutRuns string
+	}{
+		{"combined", pdf2html.TableExportOptions{}, "Fee for March", "Bank Transfer"},
+		{"bold only", pdf2html.TableExportOptions{Bold: pdf2html.BoldOnly}, "Fee", "Bank"},
+		{"plain only", pdf2html.TableExportOptions{Bold: pdf2html.BoldPlainOnly}, "for March", "Transfer"},
+		{"marked", pdf2html.TableExportOptions{Bold: pdf2html.BoldMarked}, "**Fee** for March", "**Bank** Transfer"},
+		{"marker", pdf2html.TableExportOptions{Bold: pdf2html.BoldMarked, BoldMarker: "*"}, "*Fee* for March", "*Bank* Transfer"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, *test.options.CellText(cell))
+			assert.Equal(t, test.expectedWithoutRuns, *test.options.CellText(withoutRuns))
+		})
+	}
+
+	t.Run("lines and nil cells", func(t *testing.T) {
+		lines := &pdf2html.PdfXmlTableEntryContent{Lines: []pdf2html.PdfXmlText{
+			{Text: pointerHelperFn("Transfer ")},
+			{Text: pointerHelperFn(" to savings")},
+		}}
+		assert.Equal(t, "Transfer to savings", *pdf2html.TableExportOptions{}.CellText(lines))
+		assert.Nil(t, pdf2html.TableExportOptions{}.CellText(nil))
+	})
+}
+
+func TestWriteTableCSV(t *testing.T) {
+	t.Run("csv", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := pdf2html.WriteTableCSV(&buffer, exportEntries()[1:], pdf2html.TableExportOptions{
+			Header: []string{"Date", "Description", "Amount"},
+			Empty:  "-",
+			Comma:  ';',
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "Date;Description;Amount\n01.02.;\"Fee for \"\"March\"\"\";-\n02.02.;Bank Transfer;12.50\n", buffer.String())
+	})
+
+	t.Run("tsv", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := pdf2html.WriteTableTSV(&buffer, exportEntries(), pdf2html.TableExportOptions{Comma: ';'})
+		assert.Nil(t, err)
+		assert.Equal(t, "Date\tDescription\tAmount\n01.02.\t\"Fee for \"\"March\"\"\"\t\n02.02.\tBank Transfer\t12.50\n", buffer.String())
+	})
+}
+
+func TestWriteTableJSON(t *testing.T) {
+	t.Run("objects of the header row", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := pdf2html.WriteTableJSON(&buffer, exportEntries(), pdf2html.TableExportOptions{HeaderRows: 1, JSONNull: true})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `[
+			{"Date": "01.02.", "Description": "Fee for \"March\"", "Amount": null},
+			{"Date": "02.02.", "Description": "Bank Transfer", "Amount": "12.50"}
+		]`, buffer.String())
+	})
+
+	t.Run("keys in the order of the columns", func(t *testing.T) {
+		entries := []*pdf2html.PdfXmlTableEntry{
+			tableEntry(1, "Date", "Amount", "", "Amount", "Amount_2"),
+			tableEntry(1, "01.02.", "12.50", "x", "13.50", "14.50"),
+		}
+
+		var buffer bytes.Buffer
+		err := pdf2html.WriteTableJSON(&buffer, entries, pdf2html.TableExportOptions{HeaderRows: 1})
+		assert.Nil(t, err)
+		assert.Equal(t, `[
+  {
+    "Date": "01.02.",
+    "Amount": "12.50",
+    "2": "x",
+    "Amount_2": "13.50",
+    "Amount_2_2": "14.50"
+  }
+]
+`, buffer.String())
+	})
+
+	t.Run("arrays", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := pdf2html.WriteTableJSON(&buffer, exportEntries()[1:], pdf2html.TableExportOptions{Bold: pdf2html.BoldOnly})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `[["", "Fee", ""], ["", "Bank", ""]]`, buffer.String())
+	})
+
+	t.Run("empty table", func(t *testing.T) {
+		var buffer bytes.Buffer
+		err := pdf2html.WriteTableJSON(&buffer, nil, pdf2html.TableExportOptions{HeaderRows: 1})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `[]`, buffer.String())
+	})
+}
+
+func TestWriteTableXLSX(t *testing.T) {
+	var buffer bytes.Buffer
+	err := pdf2html.WriteTableXLSX(&buffer, exportEntries(), pdf2html.TableExportOptions{
+		HeaderRows: 1,
+		SheetName:  "Statement & Co",
+		Numbers:    true,
+	})
+	assert.Nil(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert.Nil(t, err)
+
+	files := map[string]string{}
+	for _, file := range reader.File {
+		content, err := file.Open()
+		assert.Nil(t, err)
+		data, err := io.ReadAll(content)
+		assert.Nil(t, err)
+		files[file.Name] = string(data)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files, "_rels/.rels")
+	assert.Contains(t, files, "xl/_rels/workbook.xml.rels")
+	assert.Contains(t, files, "xl/styles.xml")
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Statement &amp; Co" sheetId="1" r:id="rId1"/>`)
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Date</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">Fee for &#34;March&#34;</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="C3"><v>12.50</v></c>`)
+	assert.Contains(t, sheet, `<c r="C2" t="inlineStr"><is><t xml:space="preserve"></t></is></c>`)
+
+	t.Run("invalid sheet names", func(t *testing.T) {
+		for name, message := range map[string]string{
+			"Statement of January and February": "longer than 31 characters",
+			"2024/01":                           `contains one of []:*?/\`,
+			"Statement [EUR]":                   `contains one of []:*?/\`,
+			"'Statement'":                       "starts or ends with an apostrophe",
+		} {
+			var buffer bytes.Buffer
+			err := pdf2html.WriteTableXLSX(&buffer, exportEntries(), pdf2html.TableExportOptions{SheetName: name})
+			assert.EqualError(t, err, fmt.Sprintf("invalid sheet name %q: %s", name, message))
+			assert.Zero(t, buffer.Len())
+		}
+
+		// 31 characters, counted as characters and not as bytes
+		var buffer bytes.Buffer
+		err := pdf2html.WriteTableXLSX(&buffer, exportEntries(), pdf2html.TableExportOptions{SheetName: "Übersicht der Umsätze März 2024"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("only finite decimals are numbers", func(t *testing.T) {
+		cells := []string{"-12.50", "7", "NaN", "Inf", "-infinity", "0x1p-2", "1e400", "1e5", "+3", ".5", "12."}
+		entries := []*pdf2html.PdfXmlTableEntry{tableEntry(1, cells...)}
+
+		var buffer bytes.Buffer
+		err := pdf2html.WriteTableXLSX(&buffer, entries, pdf2html.TableExportOptions{Numbers: true})
+		assert.Nil(t, err)
+
+		reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		assert.Nil(t, err)
+		content, err := reader.Open("xl/worksheets/sheet1.xml")
+		assert.Nil(t, err)
+		data, err := io.ReadAll(content)
+		assert.Nil(t, err)
+
+		sheet := string(data)
+		assert.Contains(t, sheet, `<c r="A1"><v>-12.50</v></c>`)
+		assert.Contains(t, sheet, `<c r="B1"><v>7</v></c>`)
+		for i, cell := range cells[2:] {
+			assert.Contains(t, sheet, fmt.Sprintf(`<c r="%c1" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, 'C'+i, cell))
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package pdf2html
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// Version of the JSON schema of PdfXmlData, increased on breaking changes
+const JSONSchemaVersion = 1
+
+// JSON schema of the serialized PdfXmlData (see schema.json)
+//
+//go:embed schema.json
+var JSONSchema string
+
+// Alias without the JSON methods to avoid a recursion while (un)marshalling
+type pdfXmlDataJSON PdfXmlData
+
+type versionedPdfXmlData struct {
+	SchemaVersion int `json:"schemaVersion"`
+	pdfXmlDataJSON
+}
+
+// Marshals the data with the current schema version
+func (d PdfXmlData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(versionedPdfXmlData{
+		SchemaVersion:  JSONSchemaVersion,
+		pdfXmlDataJSON: pdfXmlDataJSON(d),
+	})
+}
+
+// Unmarshals the data, a missing schema version is treated as the current one
+func (d *PdfXmlData) UnmarshalJSON(content []byte) error {
+	var data versionedPdfXmlData
+	err := json.Unmarshal(content, &data)
+	if err != nil {
+		return err
+	}
+
+	if data.SchemaVersion > JSONSchemaVersion {
+		return fmt.Errorf("unsupported schema version %d", data.SchemaVersion)
+	}
+
+	*d = PdfXmlData(data.pdfXmlDataJSON)
+
+	return nil
+}
+
+// Reloads stored JSON into the data, e.g. to run ExtractTableContent without pdftohtml
+func ParseJSON(content []byte) (*PdfXmlData, error) {
+	var data PdfXmlData
+	err := json.Unmarshal(content, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,137 @@
+package pdf2html_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+// XMLName is not part of the JSON schema and therefore empty after a reload
+func withoutXMLNames(data pdf2html.PdfXmlData) pdf2html.PdfXmlData {
+	data.XMLName = xml.Name{}
+
+	pages := []pdf2html.PdfXmlPage{}
+	for _, page := range data.Pages {
+		page.XMLName = xml.Name{}
+
+		fontSpecs := []pdf2html.PdfXmlFontSpec{}
+		for _, fontSpec := range page.FontSpecs {
+			fontSpec.XMLName = xml.Name{}
+			fontSpecs = append(fontSpecs, fontSpec)
+		}
+		if page.FontSpecs == nil {
+			fontSpecs = nil
+		}
+		page.FontSpecs = fontSpecs
+
+		texts := []pdf2html.PdfXmlText{}
+		for _, text := range page.Texts {
+			text.XMLName = xml.Name{}
+			texts = append(texts, text)
+		}
+		page.Texts = texts
+
+		if page.Images != nil {
+			images := []pdf2html.PdfXmlImage{}
+			for _, image := range page.Images {
+				image.XMLName = xml.Name{}
+				images = append(images, image)
+			}
+			page.Images = images
+		}
+
+		pages = append(pages, page)
+	}
+	data.Pages = pages
+
+	return data
+}
+
+func TestJSON(t *testing.T) {
+	t.Helper()
+
+	t.Run("Marshal without XMLName and with schema version", func(t *testing.T) {
+		content, err := json.Marshal(expectedXMLObj)
+		assert.Nil(t, err)
+
+		raw := map[string]any{}
+		assert.Nil(t, json.Unmarshal(content, &raw))
+		assert.Equal(t, float64(pdf2html.JSONSchemaVersion), raw["schemaVersion"])
+		assert.Equal(t, "poppler", raw["producer"])
+		assert.Equal(t, "24.11.0", raw["version"])
+		assert.NotContains(t, string(content), "XMLName")
+
+		page := raw["pages"].([]any)[0].(map[string]any)
+		assert.Equal(t, float64(1), page["number"])
+		assert.Equal(t, float64(892), page["width"])
+
+		text := page["texts"].([]any)[1].(map[string]any)
+		assert.Equal(t, map[string]any{
+			"top":      float64(471),
+			"left":     float64(106),
+			"width":    float64(188),
+			"height":   float64(19),
+			"font":     float64(0),
+			"text":     " mixed",
+			"boldText": "Test",
+			"runs": []any{
+				map[string]any{"text": "Test", "bold": true},
+				map[string]any{"text": " mixed"},
+			},
+		}, text)
+	})
+
+	t.Run("Round trip", func(t *testing.T) {
+		content, err := json.Marshal(expectedXMLObj)
+		assert.Nil(t, err)
+
+		data, err := pdf2html.ParseJSON(content)
+		assert.Nil(t, err)
+		assert.Equal(t, withoutXMLNames(expectedXMLObj), *data)
+	})
+
+	t.Run("Extract table content on reloaded data", func(t *testing.T) {
+		content, err := json.Marshal(pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{xmlPage}})
+		assert.Nil(t, err)
+
+		data, err := pdf2html.ParseJSON(content)
+		assert.Nil(t, err)
+
+		option := pdf2html.PdfXmlTableOption{
+			From:                  50,
+			To:                    300,
+			Columns:               3,
+			GetColumnFunc:         pdf2html.GetColumnCalculationWithVariance([]int{100, 200, 400}, 20),
+			AllowedHeightVariance: 5,
+		}
+		assert.Equal(t, xmlPage.ExtractTableContent(option), data.Pages[0].ExtractTableContent(option))
+	})
+
+	t.Run("Missing schema version", func(t *testing.T) {
+		data, err := pdf2html.ParseJSON([]byte(`{"producer":"poppler","pages":[{"number":3}]}`))
+		assert.Nil(t, err)
+		assert.Equal(t, "poppler", *data.Producer)
+		assert.Equal(t, 3, *data.Pages[0].PageNumber)
+	})
+
+	t.Run("Unsupported schema version", func(t *testing.T) {
+		data, err := pdf2html.ParseJSON([]byte(`{"schemaVersion":2}`))
+		assert.Nil(t, data)
+		assert.Equal(t, "unsupported schema version 2", err.Error())
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		data, err := pdf2html.ParseJSON([]byte(`{"pages":"invalid"}`))
+		assert.Nil(t, data)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Schema is valid JSON", func(t *testing.T) {
+		schema := map[string]any{}
+		assert.Nil(t, json.Unmarshal([]byte(pdf2html.JSONSchema), &schema))
+		assert.Equal(t, "PdfXmlData", schema["title"])
+	})
+}
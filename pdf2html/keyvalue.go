@@ -0,0 +1,190 @@
+package pdf2html
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// How the label and the value of a KeyValue are laid out
+type KeyValueLayout string
+
+const (
+	KeyValueInline     KeyValueLayout = "inline"     // label and value in the same text, e.g. "Account: 12345"
+	KeyValueHorizontal KeyValueLayout = "horizontal" // value right of the label in the same line
+	KeyValueVertical   KeyValueLayout = "vertical"   // value below the label
+)
+
+type KVOptions struct {
+	MaxGap     int              // maximum distance between a label and the value right of it (default 200)
+	MaxLineGap int              // maximum distance between a label and the value below it (default the height of the label)
+	BoldLabels bool             // bold texts without a colon are labels too, if the value is not bold
+	FontSpecs  []PdfXmlFontSpec // fonts of the document, pdftohtml writes a font only on the first page using it
+}
+
+// Label with its value found on a page
+type KeyValue struct {
+	Key       string // label without the colon
+	Value     string
+	KeyRect   Rect
+	ValueRect Rect // for inline pairs both boxes are estimated from the position of the colon
+	Layout    KeyValueLayout
+}
+
+const defaultKeyValueGap = 200
+
+// Label and value in one text, the label has to contain a letter and the colon has to be followed
+// by a space, so times like 12:30 and urls are not split
+var inlineKeyValueRegexp = regexp.MustCompile(`^([^:]*\pL[^:]*?)\s*:\s+(\S.*)$`)
+
+// Identifies a text of a page by its box and content
+type textKey struct {
+	rect    Rect
+	content string
+}
+
+func newTextKey(text PdfXmlText) textKey {
+	return textKey{rect: text.Rect(), content: text.Content()}
+}
+
+// Get the label and value pairs of the page in reading order of the labels. Labels end with a
+// colon, or are bold with the BoldLabels option. The value of a label is the nearest text right
+// of it in the same line or, if there is none, the nearest text below it. Texts containing a
+// label and a value separated by a colon are split
+func (p PdfXmlPage) ExtractKeyValues(options KVOptions) []KeyValue {
+	if options.MaxGap == 0 {
+		options.MaxGap = defaultKeyValueGap
+	}
+	fonts := fontSpecsByID(append([]PdfXmlPage{{FontSpecs: options.FontSpecs}}, p))
+
+	positioned := PdfXmlPage{}
+	for _, text := range p.Texts {
+		if text.Top != nil && text.Left != nil {
+			positioned.Texts = append(positioned.Texts, text)
+		}
+	}
+	index := NewSpatialIndex(positioned)
+
+	used := map[textKey]bool{}
+	isUsed := func(text PdfXmlText) bool {
+		return used[newTextKey(text)]
+	}
+	use := func(texts ...PdfXmlText) {
+		for _, text := range texts {
+			used[newTextKey(text)] = true
+		}
+	}
+
+	result := []KeyValue{}
+	for _, text := range positioned.getSortedTexts(minInt, maxInt) {
+		if isUsed(text) {
+			continue
+		}
+
+		content := strings.TrimSpace(text.Content())
+		if match := inlineKeyValueRegexp.FindStringSubmatch(content); match != nil {
+			keyRect, valueRect := splitRect(text.Rect(), content, match[1], match[2])
+			result = append(result, KeyValue{
+				Key:       match[1],
+				Value:     strings.TrimSpace(match[2]),
+				KeyRect:   keyRect,
+				ValueRect: valueRect,
+				Layout:    KeyValueInline,
+			})
+			use(text)
+			continue
+		}
+
+		colon := isColonLabel(content)
+		bold := options.BoldLabels && hasLetter(content) && isBoldText(text, fonts)
+		if !colon && !bold {
+			continue
+		}
+
+		// The value must not be a label itself, a bold label needs a value that is not bold
+		isValue := func(value PdfXmlText) bool {
+			valueContent := strings.TrimSpace(value.Content())
+			if isUsed(value) || valueContent == "" || isColonLabel(valueContent) || inlineKeyValueRegexp.MatchString(valueContent) {
+				return false
+			}
+
+			return colon || !isBoldText(value, fonts)
+		}
+
+		rect := text.Rect()
+		var value *PdfXmlText
+		layout := KeyValueHorizontal
+		if right := index.RightOf(text); len(right) != 0 && right[0].Rect().Left-rect.Right <= options.MaxGap && isValue(right[0]) {
+			value = &right[0]
+		} else {
+			maxLineGap := options.MaxLineGap
+			if maxLineGap == 0 {
+				maxLineGap = rect.Bottom - rect.Top
+			}
+
+			if below := index.Below(text); len(below) != 0 && below[0].Rect().Top-rect.Bottom <= maxLineGap && isValue(below[0]) {
+				value = &below[0]
+				layout = KeyValueVertical
+			}
+		}
+		if value == nil {
+			continue
+		}
+
+		result = append(result, KeyValue{
+			Key:       strings.TrimSpace(strings.TrimSuffix(content, ":")),
+			Value:     strings.TrimSpace(value.Content()),
+			KeyRect:   rect,
+			ValueRect: value.Rect(),
+			Layout:    layout,
+		})
+		use(text, *value)
+	}
+
+	return result
+}
+
+func isColonLabel(content string) bool {
+	return strings.HasSuffix(content, ":") && hasLetter(content)
+}
+
+func hasLetter(content string) bool {
+	return strings.IndexFunc(content, unicode.IsLetter) != -1
+}
+
+// Checks if the whole text is bold, by its inline formatting or by a bold font
+func isBoldText(text PdfXmlText, fonts map[int]PdfXmlFontSpec) bool {
+	if text.Font != nil {
+		if fontSpec, ok := fonts[*text.Font]; ok && fontSpec.Family != nil && strings.Contains(strings.ToLower(*fontSpec.Family), "bold") {
+			return true
+		}
+	}
+
+	if len(text.Runs) != 0 {
+		for _, run := range text.Runs {
+			if !run.Bold && strings.TrimSpace(run.Text) != "" {
+				return false
+			}
+		}
+		return true
+	}
+
+	return text.BoldText != nil && strings.TrimSpace(*text.BoldText) != "" &&
+		(text.Text == nil || strings.TrimSpace(*text.Text) == "")
+}
+
+// Estimates the boxes of the label and the value in one text by the share of their characters
+func splitRect(rect Rect, content, key, value string) (Rect, Rect) {
+	length := utf8.RuneCountInString(content)
+	if length == 0 {
+		return rect, rect
+	}
+
+	width := rect.Right - rect.Left
+	keyRect, valueRect := rect, rect
+	keyRect.Right = rect.Left + width*utf8.RuneCountInString(key)/length
+	valueRect.Left = rect.Right - width*utf8.RuneCountInString(value)/length
+
+	return keyRect, valueRect
+}
@@ -0,0 +1,85 @@
+package pdf2html_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractKeyValues(t *testing.T) {
+	t.Run("colon labels", func(t *testing.T) {
+		page := pdf2html.PdfXmlPage{Texts: []pdf2html.PdfXmlText{
+			textHelperFn(50, 50, "Account: DE12 3456", withWidth(200)),
+			textHelperFn(80, 50, "Statement no.:", withWidth(80)),
+			textHelperFn(80, 150, "17", withWidth(30)),
+			textHelperFn(110, 50, "Date:", withWidth(60)),
+			textHelperFn(110, 300, "Period:", withWidth(60)),
+			textHelperFn(126, 50, "01.03.2024", withWidth(70)),
+			textHelperFn(126, 300, "March 2024", withWidth(120)),
+			textHelperFn(160, 50, "Notes:", withWidth(60)),
+			textHelperFn(160, 500, "far away", withWidth(60)),
+			textHelperFn(200, 50, "Opened 12:30", withWidth(60)),
+			{Text: pointerHelperFn("Balance: 12")},
+		}}
+
+		assert.Equal(t, []pdf2html.KeyValue{
+			{
+				Key:       "Account",
+				Value:     "DE12 3456",
+				KeyRect:   pdf2html.Rect{Left: 50, Top: 50, Right: 127, Bottom: 62},
+				ValueRect: pdf2html.Rect{Left: 150, Top: 50, Right: 250, Bottom: 62},
+				Layout:    pdf2html.KeyValueInline,
+			},
+			{
+				Key:       "Statement no.",
+				Value:     "17",
+				KeyRect:   pdf2html.Rect{Left: 50, Top: 80, Right: 130, Bottom: 92},
+				ValueRect: pdf2html.Rect{Left: 150, Top: 80, Right: 180, Bottom: 92},
+				Layout:    pdf2html.KeyValueHorizontal,
+			},
+			{
+				Key:       "Date",
+				Value:     "01.03.2024",
+				KeyRect:   pdf2html.Rect{Left: 50, Top: 110, Right: 110, Bottom: 122},
+				ValueRect: pdf2html.Rect{Left: 50, Top: 126, Right: 120, Bottom: 138},
+				Layout:    pdf2html.KeyValueVertical,
+			},
+			{
+				Key:       "Period",
+				Value:     "March 2024",
+				KeyRect:   pdf2html.Rect{Left: 300, Top: 110, Right: 360, Bottom: 122},
+				ValueRect: pdf2html.Rect{Left: 300, Top: 126, Right: 420, Bottom: 138},
+				Layout:    pdf2html.KeyValueVertical,
+			},
+		}, page.ExtractKeyValues(pdf2html.KVOptions{}))
+
+		pairs := page.ExtractKeyValues(pdf2html.KVOptions{MaxGap: 500})
+		assert.Len(t, pairs, 5)
+		assert.Equal(t, "far away", pairs[4].Value)
+	})
+
+	t.Run("bold labels", func(t *testing.T) {
+		boldFont := textHelperFn(80, 50, "Customer", withWidth(60))
+		boldFont.Font = pointerHelperFn(3)
+
+		page := pdf2html.PdfXmlPage{Texts: []pdf2html.PdfXmlText{
+			textHelperFn(50, 50, "Delivery", withWidth(60), withBold()),
+			textHelperFn(50, 150, "LS-4711", withWidth(60)),
+			boldFont,
+			textHelperFn(80, 150, "ACME Ltd", withWidth(60)),
+			textHelperFn(110, 50, "Heading", withWidth(60), withBold()),
+			textHelperFn(110, 150, "Bold value", withWidth(60), withBold()),
+		}}
+		options := pdf2html.KVOptions{
+			BoldLabels: true,
+			FontSpecs:  []pdf2html.PdfXmlFontSpec{{ID: pointerHelperFn(3), Family: pointerHelperFn("ABCDEF+Arial-BoldMT")}},
+		}
+
+		pairs := page.ExtractKeyValues(options)
+		assert.Len(t, pairs, 2)
+		assert.Equal(t, []string{"Delivery", "LS-4711", "Customer", "ACME Ltd"}, []string{pairs[0].Key, pairs[0].Value, pairs[1].Key, pairs[1].Value})
+
+		assert.Empty(t, page.ExtractKeyValues(pdf2html.KVOptions{}))
+	})
+}
@@ -0,0 +1,275 @@
+package pdf2html
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type MarkdownOptions struct {
+	Tables     []MarkdownTable // tables rendered as GFM tables, their texts are not part of the other elements
+	PageBreaks bool            // separate the pages with a thematic break
+}
+
+// Table extracted on every page with content in its area
+type MarkdownTable struct {
+	Option PdfXmlTableOption
+	Header []string // header cells, the first row is the header if not set
+}
+
+type markdownTable struct {
+	top  int
+	rows [][]string
+}
+
+var (
+	markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "`", "\\`", "<", `\<`)
+
+	// Starts of a paragraph that would be read as another block
+	markdownBlockRegexp   = regexp.MustCompile(`^(#{1,6}(\s|$)|>|[-+](\s|$))`)
+	markdownOrderedRegexp = regexp.MustCompile(`^([0-9]{1,9})([.)])(\s|$)`)
+	orderedMarkerRegexp   = regexp.MustCompile(`^\(?([0-9]{1,3})[.)]$`)
+)
+
+// Get the document as CommonMark with GFM tables. The elements of Structure are rendered as headings,
+// paragraphs and lists, bold, italic and linked runs of the texts keep their formatting. Captions are
+// rendered as paragraphs. The tables are placed in front of the first element of their page below them
+func (d PdfXmlData) ToMarkdown(options MarkdownOptions) string {
+	tables := map[int][]markdownTable{}
+	skip := map[int]map[textKey]bool{}
+	for i, page := range d.Pages {
+		number := i + 1
+		if page.PageNumber != nil {
+			number = *page.PageNumber
+		}
+		skip[number] = map[textKey]bool{}
+
+		for _, table := range options.Tables {
+			entries := page.ExtractTableContent(table.Option)
+			if len(entries) == 0 {
+				continue
+			}
+
+			rendered := markdownTable{top: maxInt}
+			if len(table.Header) != 0 {
+				rendered.rows = append(rendered.rows, table.Header)
+			}
+			for _, entry := range entries {
+				row := []string{}
+				for _, content := range entry.Content {
+					row = append(row, markdownCell(content))
+					if content == nil {
+						continue
+					}
+					for _, line := range content.Lines {
+						skip[number][newTextKey(line)] = true
+						rendered.top = min(rendered.top, line.Rect().Top)
+					}
+				}
+				rendered.rows = append(rendered.rows, row)
+			}
+			tables[number] = append(tables[number], rendered)
+		}
+
+		sort.SliceStable(tables[number], func(a, b int) bool {
+			return tables[number][a].top < tables[number][b].top
+		})
+	}
+
+	elements, lines := d.structure(func(page int, text PdfXmlText) bool {
+		return skip[page][newTextKey(text)]
+	})
+
+	pages := []string{}
+	for i, page := range d.Pages {
+		number := i + 1
+		if page.PageNumber != nil {
+			number = *page.PageNumber
+		}
+
+		pageTables := tables[number]
+		blocks := []string{}
+		list := false
+		for e, element := range elements {
+			if element.Page != number {
+				continue
+			}
+
+			for len(pageTables) != 0 && pageTables[0].top < element.Rect.Top {
+				blocks = append(blocks, renderMarkdownTable(pageTables[0].rows))
+				pageTables = pageTables[1:]
+				list = false
+			}
+
+			text := renderMarkdownElement(element, lines[e])
+			if text == "" {
+				continue
+			}
+
+			// List items of the same list are not separated by an empty line
+			if element.Kind == ElementListItem && list {
+				blocks[len(blocks)-1] += "\n" + text
+				continue
+			}
+			list = element.Kind == ElementListItem
+			blocks = append(blocks, text)
+		}
+		for _, table := range pageTables {
+			blocks = append(blocks, renderMarkdownTable(table.rows))
+		}
+
+		if len(blocks) != 0 {
+			pages = append(pages, strings.Join(blocks, "\n\n"))
+		}
+	}
+
+	separator := "\n\n"
+	if options.PageBreaks {
+		separator = "\n\n---\n\n"
+	}
+	if len(pages) == 0 {
+		return ""
+	}
+
+	return strings.Join(pages, separator) + "\n"
+}
+
+func renderMarkdownElement(element StructuredElement, lines []structureLine) string {
+	switch element.Kind {
+	case ElementHeading:
+		return strings.Repeat("#", min(max(element.Level, 1), 6)) + " " + markdownEscaper.Replace(element.Text)
+	case ElementListItem:
+		text := markdownLines(lines)
+		match := listItemRegexp.FindStringSubmatch(element.Text)
+		if match == nil {
+			return text
+		}
+
+		marker := "-"
+		if number := orderedMarkerRegexp.FindStringSubmatch(match[1]); number != nil {
+			marker = number[1] + "."
+		}
+
+		// The marker is removed if it is not formatted
+		if rest, ok := strings.CutPrefix(text, markdownEscaper.Replace(match[1])); ok {
+			text = strings.TrimSpace(rest)
+		}
+		return marker + " " + text
+	default:
+		text := markdownLines(lines)
+		if match := markdownOrderedRegexp.FindStringSubmatch(text); match != nil {
+			return match[1] + `\` + text[len(match[1]):]
+		}
+		if markdownBlockRegexp.MatchString(text) {
+			return `\` + text
+		}
+		return text
+	}
+}
+
+func markdownLines(lines []structureLine) string {
+	parts := []string{}
+	for _, line := range lines {
+		for _, text := range line.line.Texts {
+			if part := markdownText(text); part != "" {
+				parts = append(parts, part)
+			}
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Get the trimmed content of the text with its bold, italic and linked runs as Markdown
+func markdownText(text PdfXmlText) string {
+	runs := text.Runs
+	if len(runs) == 0 {
+		if text.BoldText != nil && strings.TrimSpace(*text.BoldText) != "" {
+			runs = append(runs, PdfXmlRun{Text: strings.TrimSpace(*text.BoldText) + " ", Bold: true})
+		}
+		if text.Text != nil {
+			runs = append(runs, PdfXmlRun{Text: *text.Text})
+		}
+	}
+
+	var builder strings.Builder
+	for _, run := range runs {
+		content := strings.TrimSpace(run.Text)
+		if content == "" {
+			builder.WriteString(run.Text)
+			continue
+		}
+
+		// Spaces around the content stay outside of the delimiters
+		start := strings.Index(run.Text, content)
+		content = markdownEscaper.Replace(content)
+		// Delimiters directly after another delimiter use underscores, so both can be told apart
+		italic, bold := "*", "**"
+		if start == 0 && strings.HasSuffix(builder.String(), "*") {
+			italic, bold = "_", "__"
+		}
+		if run.Italic {
+			content = italic + content + italic
+		}
+		if run.Bold {
+			content = bold + content + bold
+		}
+		if run.Href != nil {
+			content = "[" + content + "](" + markdownDestination(*run.Href) + ")"
+		}
+
+		builder.WriteString(run.Text[:start] + content + run.Text[start+len(strings.TrimSpace(run.Text)):])
+	}
+
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// Get the link destination, destinations with spaces or parentheses are enclosed in angle brackets
+func markdownDestination(href string) string {
+	if strings.ContainsAny(href, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(href) + ">"
+	}
+
+	return href
+}
+
+func markdownCell(content *PdfXmlTableEntryContent) string {
+	if content == nil {
+		return ""
+	}
+
+	lines := content.Lines
+	if len(lines) == 0 {
+		lines = []PdfXmlText{{Text: content.Text, BoldText: content.BoldText, Runs: content.Runs}}
+	}
+
+	parts := []string{}
+	for _, line := range lines {
+		if part := markdownText(line); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.ReplaceAll(strings.Join(parts, " "), "|", `\|`)
+}
+
+// Get the GFM table, the first row is the header
+func renderMarkdownTable(rows [][]string) string {
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	lines := []string{}
+	for i, row := range rows {
+		cells := make([]string, columns)
+		copy(cells, row)
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
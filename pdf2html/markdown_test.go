@@ -0,0 +1,153 @@
+package pdf2html_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+var markdownXML = `<pdf2xml producer="poppler" version="24.11.0">
+<page number="1" position="absolute" top="0" left="0" height="1262" width="892">
+	<fontspec id="0" size="12" family="Arial" color="#000000"/>
+	<fontspec id="1" size="18" family="Arial" color="#000000"/>
+	<text top="50" left="50" width="300" height="20" font="1">Report</text>
+	<text top="90" left="50" width="400" height="12" font="0">Text with <b>bold</b> and <i>italic</i> parts</text>
+	<text top="104" left="50" width="400" height="12" font="0">and a <a href="https://example.com/a_b">link</a> with a_b.</text>
+	<text top="130" left="50" width="200" height="12" font="0">• first</text>
+	<text top="144" left="50" width="200" height="12" font="0">• second</text>
+	<text top="170" left="50" width="200" height="12" font="0">Item</text>
+	<text top="170" left="300" width="50" height="12" font="0">Price</text>
+	<text top="184" left="50" width="200" height="12" font="0"><b>Widget</b></text>
+	<text top="184" left="300" width="50" height="12" font="0">1|2</text>
+	<text top="210" left="50" width="200" height="12" font="0">2) third</text>
+	<text top="240" left="50" width="300" height="12" font="0"># not a heading</text>
+</page>
+<page number="2" position="absolute" top="0" left="0" height="1262" width="892">
+	<text top="50" left="50" width="200" height="12" font="0">Second page</text>
+</page>
+</pdf2xml>`
+
+func TestToMarkdown(t *testing.T) {
+	data := pdf2html.PdfXmlData{}
+	assert.Nil(t, xml.Unmarshal([]byte(markdownXML), &data))
+
+	options := pdf2html.MarkdownOptions{
+		Tables: []pdf2html.MarkdownTable{{
+			Option: pdf2html.PdfXmlTableOption{
+				From:                  165,
+				To:                    195,
+				Columns:               2,
+				GetColumnFunc:         pdf2html.GetColumnCalculationInRanges([]pdf2html.GetColumnCalculationInRangesOption{{From: 0, To: 100}, {From: 250, To: 350}}),
+				AllowedHeightVariance: 5,
+			},
+		}},
+		PageBreaks: true,
+	}
+
+	assert.Equal(t, `# Report
+
+Text with **bold** and *italic* parts and a [link](https://example.com/a_b) with a\_b.
+
+- first
+- second
+
+| Item | Price |
+| --- | --- |
+| **Widget** | 1\|2 |
+
+2. third
+
+\# not a heading
+
+---
+
+Second page
+`, data.ToMarkdown(options))
+
+	t.Run("header and without tables", func(t *testing.T) {
+		options.Tables[0].Header = []string{"Name", "Amount"}
+		options.PageBreaks = false
+		assert.Contains(t, data.ToMarkdown(options), "| Name | Amount |\n| --- | --- |\n| Item | Price |\n| **Widget** | 1\\|2 |\n\n2. third")
+		assert.Contains(t, data.ToMarkdown(options), "\\# not a heading\n\nSecond page\n")
+
+		// Without the table option the columns of the table are read one after the other
+		assert.NotContains(t, data.ToMarkdown(pdf2html.MarkdownOptions{}), "| --- |")
+	})
+
+	t.Run("adjacent formatting", func(t *testing.T) {
+		data := pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{{Texts: []pdf2html.PdfXmlText{{
+			Top:  pointerHelperFn(50),
+			Left: pointerHelperFn(50),
+			Runs: []pdf2html.PdfXmlRun{{Text: "Text "}, {Text: "bold", Bold: true}, {Text: "italic", Italic: true}, {Text: "bold", Bold: true}, {Text: " [1]"}},
+		}}}}}
+		assert.Equal(t, "Text **bold**_italic_**bold** \\[1\\]\n", data.ToMarkdown(pdf2html.MarkdownOptions{}))
+	})
+
+	t.Run("empty document", func(t *testing.T) {
+		assert.Equal(t, "", pdf2html.PdfXmlData{}.ToMarkdown(pdf2html.MarkdownOptions{}))
+	})
+}
+
+func TestGetMarkdown(t *testing.T) {
+	t.Helper()
+
+	t.Run("Check for successful GetMarkdown", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		o, err := client.GetMarkdown("filename", pdf2html.Options{})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"pdftohtml", "-xml", "filename", "test-mkdir-tmpdir"}, wrapperFnMock.GetLastInput().Cmd.Args)
+		assert.Equal(t, "Only text\n\n# Test Bold\n\n**Test** mixed\n\nOnly text p2\n\n**Test Bold** p2 **Test** mixed p2\n", *o)
+	})
+
+	t.Run("Check for GetMarkdownWithOptions with a table", func(t *testing.T) {
+		setupXmlTests()
+		osMock.Mock.ReadFile.Reset()
+		osMock.Mock.ReadFile.AddReturnValue(pointerHelperFn([]byte(markdownXML)))
+		client, _ := pdf2html.NewClient()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		o, err := client.GetMarkdownWithOptions("filename", pdf2html.Options{LastPage: pointerHelperFn(1)}, pdf2html.MarkdownOptions{
+			Tables: []pdf2html.MarkdownTable{{
+				Option: pdf2html.PdfXmlTableOption{
+					From:                  165,
+					To:                    195,
+					Columns:               2,
+					GetColumnFunc:         pdf2html.GetColumnCalculationInRanges([]pdf2html.GetColumnCalculationInRangesOption{{From: 0, To: 100}, {From: 250, To: 350}}),
+					AllowedHeightVariance: 5,
+				},
+				Header: []string{"Name", "Amount"},
+			}},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"pdftohtml", "-l", "1", "-xml", "filename", "test-mkdir-tmpdir"}, wrapperFnMock.GetLastInput().Cmd.Args)
+		assert.Contains(t, *o, "| Name | Amount |\n| --- | --- |\n| Item | Price |\n| **Widget** | 1\\|2 |\n")
+	})
+
+	t.Run("Get fails", func(t *testing.T) {
+		setupXmlTests()
+		client, _ := pdf2html.NewClient()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return nil, nil, fmt.Errorf("GET error")
+		}
+
+		runMock.AddReturnValue(&fn)
+		o, err := client.GetMarkdown("filename", pdf2html.Options{})
+		assert.Equal(t, "GET error", err.Error())
+		assert.Nil(t, o)
+	})
+}
@@ -0,0 +1,189 @@
+package pdf2html
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Area of a table on a single page of a multi-page table
+type PdfXmlTableBand struct {
+	From, To   int                // In what area should the table be located
+	Start, End *PdfXmlTableAnchor // anchors of the area
+}
+
+type MultiPageTableOption struct {
+	PdfXmlTableOption // options of the table on every page, From, To, Start and End are the default band
+
+	Bands map[int]PdfXmlTableBand // bands of single pages by page number, overriding the default band
+
+	FirstPage, LastPage int // page numbers the table is located on (default all pages)
+
+	HeaderRows          int  // number of header rows at the top of the first page that are dropped on the following pages (default 1)
+	KeepRepeatedHeaders bool // do not drop the header rows that are repeated at the top of the following pages
+	KeepRepeatedFooters bool // do not drop the footer rows that are repeated at the bottom of the pages
+}
+
+// Extracts a table that spans several pages. The table content of every page is extracted with
+// its own band, repeated header and footer rows are dropped and the entries of all pages are merged
+func (d PdfXmlData) ExtractTable(option MultiPageTableOption) []*PdfXmlTableEntry {
+	table, _ := d.ExtractTableWithAreas(option)
+	return table
+}
+
+// Extracts a table that spans several pages like ExtractTable and reports the resolved area of every page
+func (d PdfXmlData) ExtractTableWithAreas(option MultiPageTableOption) ([]*PdfXmlTableEntry, []PdfXmlTableArea) {
+	fonts := fontSpecsByID(d.Pages)
+
+	pages := [][]*PdfXmlTableEntry{}
+	numbers := []int{}
+	areas := []PdfXmlTableArea{}
+	for _, page := range d.Pages {
+		number := 0
+		if page.PageNumber != nil {
+			number = *page.PageNumber
+		}
+		if (option.FirstPage != 0 && number < option.FirstPage) || (option.LastPage != 0 && number > option.LastPage) {
+			continue
+		}
+
+		band, ok := option.Bands[number]
+		if !ok {
+			band = PdfXmlTableBand{
+				From:  option.From,
+				To:    option.To,
+				Start: option.Start,
+				End:   option.End,
+			}
+		}
+
+		pageOption := option.PdfXmlTableOption
+		pageOption.From, pageOption.To = band.From, band.To
+		pageOption.Start, pageOption.End = band.Start, band.End
+
+		entries, area := page.extractTableContent(pageOption, fonts)
+		pages = append(pages, entries)
+		numbers = append(numbers, number)
+		areas = append(areas, area)
+	}
+
+	if !option.KeepRepeatedHeaders {
+		headerRows := option.HeaderRows
+		if headerRows == 0 {
+			headerRows = 1
+		}
+		dropRepeatedHeaders(pages, headerRows)
+	}
+	if !option.KeepRepeatedFooters {
+		dropRepeatedFooters(pages, numbers)
+	}
+
+	table := []*PdfXmlTableEntry{}
+	for _, entries := range pages {
+		table = append(table, entries...)
+	}
+
+	return table, areas
+}
+
+// Key of an entry to compare the rows of different pages
+func (e PdfXmlTableEntry) key() string {
+	cells := []string{}
+	for _, content := range e.Content {
+		if content == nil {
+			cells = append(cells, "")
+			continue
+		}
+		cells = append(cells, strings.TrimSpace(content.Content()))
+	}
+
+	return strings.Join(cells, "\t")
+}
+
+// Check if both rows have the same columns with the same content
+func (e PdfXmlTableEntry) isSameRow(other PdfXmlTableEntry) bool {
+	return len(e.Content) == len(other.Content) && e.key() == other.key()
+}
+
+// Drops the rows at the top of the following pages that are equal to the header rows at the top of the first page
+func dropRepeatedHeaders(pages [][]*PdfXmlTableEntry, headerRows int) {
+	if len(pages) < 2 {
+		return
+	}
+
+	headers := pages[0][:min(headerRows, len(pages[0]))]
+	for i := 1; i < len(pages); i++ {
+		count := 0
+		for count < len(headers) && count < len(pages[i]) && headers[count].isSameRow(*pages[i][count]) {
+			count++
+		}
+
+		pages[i] = pages[i][count:]
+	}
+}
+
+// Drops the rows at the bottom of the pages that are repeated at the bottom of a neighbour page.
+// Only rows with a single cell containing letters or just the page number are footers, the page
+// number is ignored, so footers like "Page 1 of 12" are detected and table rows are kept
+func dropRepeatedFooters(pages [][]*PdfXmlTableEntry, numbers []int) {
+	if len(pages) < 2 {
+		return
+	}
+
+	counts := make([]int, len(pages))
+	for i := 1; i < len(pages); i++ {
+		count := commonFooterRows(pages[i-1], pages[i], numbers[i-1], numbers[i])
+		counts[i-1] = max(counts[i-1], count)
+		counts[i] = max(counts[i], count)
+	}
+
+	for i, count := range counts {
+		pages[i] = pages[i][:len(pages[i])-count]
+	}
+}
+
+func commonFooterRows(a, b []*PdfXmlTableEntry, numberA, numberB int) int {
+	count := 0
+	for count < len(a) && count < len(b) {
+		keyA, ok := footerKey(a[len(a)-1-count], numberA)
+		if !ok {
+			break
+		}
+		keyB, ok := footerKey(b[len(b)-1-count], numberB)
+		if !ok || keyA != keyB {
+			break
+		}
+		count++
+	}
+
+	return count
+}
+
+// Get the key of a footer row with the first page number replaced by #. Rows with several cells
+// and rows of numbers are no footer
+func footerKey(e *PdfXmlTableEntry, number int) (string, bool) {
+	cells := 0
+	for _, content := range e.Content {
+		if content != nil && strings.TrimSpace(content.Content()) != "" {
+			cells++
+		}
+	}
+	if cells != 1 {
+		return "", false
+	}
+
+	key := e.key()
+	if number > 0 {
+		index := regexp.MustCompile(fmt.Sprintf(`\b%d\b`, number)).FindStringIndex(key)
+		if index != nil {
+			key = key[:index[0]] + "#" + key[index[1]:]
+		}
+	}
+
+	if strings.TrimSpace(key) == "#" || strings.IndexFunc(key, unicode.IsLetter) >= 0 {
+		return key, true
+	}
+
+	return "", false
+}
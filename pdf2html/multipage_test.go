@@ -0,0 +1,286 @@
+package pdf2html_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+func tableRow(top int, cells ...string) []pdf2html.PdfXmlText {
+	texts := []pdf2html.PdfXmlText{}
+	for i, cell := range cells {
+		if cell == "" {
+			continue
+		}
+		texts = append(texts, textHelperFn(top, 100+i*100, cell))
+	}
+
+	return texts
+}
+
+func tablePage(number int, rows ...[]pdf2html.PdfXmlText) pdf2html.PdfXmlPage {
+	texts := []pdf2html.PdfXmlText{}
+	for _, row := range rows {
+		texts = append(texts, row...)
+	}
+
+	return pdf2html.PdfXmlPage{PageNumber: pointerHelperFn(number), Texts: texts}
+}
+
+var multiPageData = pdf2html.PdfXmlData{
+	Pages: []pdf2html.PdfXmlPage{
+		tablePage(1,
+			tableRow(20, "Statement"),
+			tableRow(100, "Date", "Description", "Amount"),
+			tableRow(120, "01.01.", "Salary", "100.00"),
+			tableRow(140, "02.01.", "Rent", "-50.00"),
+			tableRow(900, "Page 1 of 3"),
+		),
+		tablePage(2,
+			tableRow(60, "Date", "Description", "Amount"),
+			tableRow(80, "03.01.", "Groceries", "-10.00"),
+			tableRow(100, "04.01.", "Insurance", "-5.00"),
+			tableRow(900, "Page 2 of 3"),
+		),
+		tablePage(3,
+			tableRow(60, "Date", "Description", "Amount"),
+			tableRow(80, "05.01.", "Transfer", "-1.00"),
+			tableRow(100, "", "Closing balance", "34.00"),
+			tableRow(900, "Page 3 of 3"),
+		),
+	},
+}
+
+func multiPageOption() pdf2html.MultiPageTableOption {
+	return pdf2html.MultiPageTableOption{
+		PdfXmlTableOption: pdf2html.PdfXmlTableOption{
+			From:                  50,
+			To:                    950,
+			Columns:               3,
+			GetColumnFunc:         pdf2html.GetColumnCalculationWithVariance([]int{100, 200, 300}, 10),
+			AllowedHeightVariance: 5,
+		},
+	}
+}
+
+func tableKeys(table []*pdf2html.PdfXmlTableEntry) [][]string {
+	rows := [][]string{}
+	for _, entry := range table {
+		cells := []string{}
+		for _, content := range entry.Content {
+			if content == nil {
+				cells = append(cells, "")
+				continue
+			}
+			cells = append(cells, content.Content())
+		}
+		rows = append(rows, cells)
+	}
+
+	return rows
+}
+
+func tablePages(table []*pdf2html.PdfXmlTableEntry) []int {
+	pages := []int{}
+	for _, entry := range table {
+		pages = append(pages, entry.Page)
+	}
+
+	return pages
+}
+
+func TestExtractTable(t *testing.T) {
+	t.Helper()
+
+	t.Run("Drop repeated headers and footers", func(t *testing.T) {
+		option := multiPageOption()
+		option.From = 90
+		option.Bands = map[int]pdf2html.PdfXmlTableBand{
+			2: {From: 50, To: 950},
+			3: {From: 50, To: 950},
+		}
+
+		table := multiPageData.ExtractTable(option)
+		assert.Equal(t, [][]string{
+			{"Date", "Description", "Amount"},
+			{"01.01.", "Salary", "100.00"},
+			{"02.01.", "Rent", "-50.00"},
+			{"03.01.", "Groceries", "-10.00"},
+			{"04.01.", "Insurance", "-5.00"},
+			{"05.01.", "Transfer", "-1.00"},
+			{"", "Closing balance", "34.00"},
+		}, tableKeys(table))
+		assert.Equal(t, []int{1, 1, 1, 2, 2, 3, 3}, tablePages(table))
+	})
+
+	t.Run("Keep numeric rows at the bottom of the pages", func(t *testing.T) {
+		data := pdf2html.PdfXmlData{
+			Pages: []pdf2html.PdfXmlPage{
+				tablePage(1,
+					tableRow(100, "Date", "Amount", "Balance"),
+					tableRow(120, "02.01.2024", "100.00", "1,100.00"),
+					tableRow(140, "31.01.2024", "120.00", "1,220.00"),
+					tableRow(900, "1"),
+				),
+				tablePage(2,
+					tableRow(100, "Date", "Amount", "Balance"),
+					tableRow(120, "01.02.2024", "-20.00", "1,200.00"),
+					tableRow(140, "28.02.2024", "130.00", "1,330.00"),
+					tableRow(900, "2"),
+				),
+			},
+		}
+
+		table := data.ExtractTable(multiPageOption())
+		assert.Equal(t, [][]string{
+			{"Date", "Amount", "Balance"},
+			{"02.01.2024", "100.00", "1,100.00"},
+			{"31.01.2024", "120.00", "1,220.00"},
+			{"01.02.2024", "-20.00", "1,200.00"},
+			{"28.02.2024", "130.00", "1,330.00"},
+		}, tableKeys(table))
+	})
+
+	t.Run("Keep data rows equal to rows of the first page", func(t *testing.T) {
+		data := pdf2html.PdfXmlData{
+			Pages: []pdf2html.PdfXmlPage{
+				tablePage(1,
+					tableRow(100, "Date", "Description", "Amount"),
+					tableRow(120, "01.01.", "Fee", "-1.00"),
+				),
+				tablePage(2,
+					tableRow(100, "Date", "Description", "Amount"),
+					tableRow(120, "01.01.", "Fee", "-1.00"),
+				),
+				tablePage(3,
+					tableRow(100, "01.01.", "Fee", "-1.00"),
+				),
+			},
+		}
+
+		table := data.ExtractTable(multiPageOption())
+		assert.Equal(t, [][]string{
+			{"Date", "Description", "Amount"},
+			{"01.01.", "Fee", "-1.00"},
+			{"01.01.", "Fee", "-1.00"},
+			{"01.01.", "Fee", "-1.00"},
+		}, tableKeys(table))
+		assert.Equal(t, []int{1, 1, 2, 3}, tablePages(table))
+	})
+
+	t.Run("Several header rows", func(t *testing.T) {
+		data := pdf2html.PdfXmlData{
+			Pages: []pdf2html.PdfXmlPage{
+				tablePage(1,
+					tableRow(100, "Date", "Description", "Amount"),
+					tableRow(120, "", "", "EUR"),
+					tableRow(140, "01.01.", "Fee", "-1.00"),
+				),
+				tablePage(2,
+					tableRow(100, "Date", "Description", "Amount"),
+					tableRow(120, "", "", "EUR"),
+					tableRow(140, "02.01.", "Fee", "-2.00"),
+				),
+			},
+		}
+
+		option := multiPageOption()
+		option.HeaderRows = 2
+
+		table := data.ExtractTable(option)
+		assert.Equal(t, [][]string{
+			{"Date", "Description", "Amount"},
+			{"", "", "EUR"},
+			{"01.01.", "Fee", "-1.00"},
+			{"02.01.", "Fee", "-2.00"},
+		}, tableKeys(table))
+
+		// Only the first row is a header by default
+		table = data.ExtractTable(multiPageOption())
+		assert.Len(t, table, 5)
+	})
+
+	t.Run("Start and end anchors", func(t *testing.T) {
+		option := multiPageOption()
+		option.Start = &pdf2html.PdfXmlTableAnchor{Text: "Date"}
+		option.End = &pdf2html.PdfXmlTableAnchor{Text: "Closing balance"}
+
+		table := multiPageData.ExtractTable(option)
+		assert.Equal(t, [][]string{
+			{"Date", "Description", "Amount"},
+			{"01.01.", "Salary", "100.00"},
+			{"02.01.", "Rent", "-50.00"},
+			{"03.01.", "Groceries", "-10.00"},
+			{"04.01.", "Insurance", "-5.00"},
+			{"05.01.", "Transfer", "-1.00"},
+		}, tableKeys(table))
+	})
+
+	t.Run("Anchor offset", func(t *testing.T) {
+		option := multiPageOption()
+		option.Start = &pdf2html.PdfXmlTableAnchor{Text: "Date", Offset: 10}
+		option.End = &pdf2html.PdfXmlTableAnchor{Text: "Page", Offset: -100}
+		option.FirstPage = 2
+		option.LastPage = 2
+
+		table := multiPageData.ExtractTable(option)
+		assert.Equal(t, [][]string{
+			{"03.01.", "Groceries", "-10.00"},
+			{"04.01.", "Insurance", "-5.00"},
+		}, tableKeys(table))
+		assert.Equal(t, []int{2, 2}, tablePages(table))
+	})
+
+	t.Run("Keep repeated rows", func(t *testing.T) {
+		option := multiPageOption()
+		option.Start = &pdf2html.PdfXmlTableAnchor{Text: "Date"}
+		option.KeepRepeatedHeaders = true
+		option.KeepRepeatedFooters = true
+		option.LastPage = 2
+
+		table := multiPageData.ExtractTable(option)
+		assert.Equal(t, [][]string{
+			{"Date", "Description", "Amount"},
+			{"01.01.", "Salary", "100.00"},
+			{"02.01.", "Rent", "-50.00"},
+			{"Page 1 of 3", "", ""},
+			{"Date", "Description", "Amount"},
+			{"03.01.", "Groceries", "-10.00"},
+			{"04.01.", "Insurance", "-5.00"},
+			{"Page 2 of 3", "", ""},
+		}, tableKeys(table))
+	})
+
+	t.Run("Single page", func(t *testing.T) {
+		option := multiPageOption()
+		option.FirstPage = 3
+
+		table := multiPageData.ExtractTable(option)
+		assert.Equal(t, [][]string{
+			{"Date", "Description", "Amount"},
+			{"05.01.", "Transfer", "-1.00"},
+			{"", "Closing balance", "34.00"},
+			{"Page 3 of 3", "", ""},
+		}, tableKeys(table))
+	})
+
+	t.Run("Missing anchors fall back to the band", func(t *testing.T) {
+		option := multiPageOption()
+		option.From = 110
+		option.To = 130
+		option.Start = &pdf2html.PdfXmlTableAnchor{Text: "unknown"}
+		option.End = &pdf2html.PdfXmlTableAnchor{Text: "unknown"}
+		option.LastPage = 1
+
+		table := multiPageData.ExtractTable(option)
+		assert.Equal(t, [][]string{
+			{"01.01.", "Salary", "100.00"},
+		}, tableKeys(table))
+	})
+
+	t.Run("Single page extraction is annotated", func(t *testing.T) {
+		table := multiPageData.Pages[1].ExtractTableContent(multiPageOption().PdfXmlTableOption)
+		assert.Equal(t, []int{2, 2, 2, 2}, tablePages(table))
+	})
+}
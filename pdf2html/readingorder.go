@@ -0,0 +1,235 @@
+package pdf2html
+
+import (
+	"sort"
+	"strings"
+)
+
+type ReadingOrderOptions struct {
+	ColumnGap int // minimum horizontal gap between columns (default the median text height)
+	BlockGap  int // minimum vertical gap between blocks (default half of the median text height)
+}
+
+// Column of a page in reading order, texts spanning several columns like a title are a column of their own
+type PdfXmlColumn struct {
+	Rect   Rect
+	Blocks []PdfXmlBlock
+}
+
+// Lines separated from the other blocks of the column by vertical space, e.g. a paragraph
+type PdfXmlBlock struct {
+	Rect  Rect
+	Lines []PdfXmlLine
+}
+
+// Texts of a line from left to right
+type PdfXmlLine struct {
+	Rect  Rect
+	Texts []PdfXmlText
+}
+
+type readingItem struct {
+	text PdfXmlText
+	rect Rect
+}
+
+// Get the texts of the page grouped into columns, blocks and lines in reading order. The page is
+// split recursively (XY-cut): first into columns at vertical gaps of at least ColumnGap running
+// through the whole region, else into bands at horizontal gaps of at least BlockGap. Adjacent bands
+// that can be split into columns together are kept together, so the columns of a two-column text
+// are read one after the other even if their paragraphs end at the same height. Texts without
+// a position are skipped
+func (p PdfXmlPage) ReadingOrder(options ReadingOrderOptions) []PdfXmlColumn {
+	items := []readingItem{}
+	heights := []int{}
+	for _, text := range p.Texts {
+		if text.Top == nil || text.Left == nil {
+			continue
+		}
+
+		rect := text.Rect()
+		items = append(items, readingItem{text: text, rect: rect})
+		if rect.Bottom > rect.Top {
+			heights = append(heights, rect.Bottom-rect.Top)
+		}
+	}
+	if len(items) == 0 {
+		return []PdfXmlColumn{}
+	}
+
+	height := 10
+	if len(heights) != 0 {
+		sort.Ints(heights)
+		height = heights[len(heights)/2]
+	}
+	if options.ColumnGap == 0 {
+		options.ColumnGap = height
+	}
+	if options.BlockGap == 0 {
+		options.BlockGap = max(1, height/2)
+	}
+
+	columns, _ := options.layout(items)
+	return columns
+}
+
+// Get the columns of the region and whether it is a single column without a vertical cut
+func (o ReadingOrderOptions) layout(items []readingItem) ([]PdfXmlColumn, bool) {
+	if parts := cutItems(items, o.ColumnGap, true); len(parts) > 1 {
+		columns := []PdfXmlColumn{}
+		for _, part := range parts {
+			partColumns, _ := o.layout(part)
+			columns = append(columns, partColumns...)
+		}
+		return columns, false
+	}
+
+	bands := o.mergeBands(cutItems(items, o.BlockGap, false))
+	if len(bands) == 1 {
+		block := newBlock(items)
+		return []PdfXmlColumn{{Rect: block.Rect, Blocks: []PdfXmlBlock{block}}}, true
+	}
+
+	// Blocks of consecutive single column bands are one column
+	columns := []PdfXmlColumn{}
+	previousSingle := false
+	for _, band := range bands {
+		bandColumns, single := o.layout(band)
+		if single && previousSingle {
+			last := &columns[len(columns)-1]
+			last.Blocks = append(last.Blocks, bandColumns[0].Blocks...)
+			last.Rect = unionRect(last.Rect, bandColumns[0].Rect)
+		} else {
+			columns = append(columns, bandColumns...)
+		}
+		previousSingle = single
+	}
+
+	return columns, len(columns) == 1 && previousSingle
+}
+
+// Merges adjacent bands while they can be split into columns together
+func (o ReadingOrderOptions) mergeBands(bands [][]readingItem) [][]readingItem {
+	merged := [][]readingItem{}
+	for _, band := range bands {
+		if len(merged) != 0 {
+			last := merged[len(merged)-1]
+			union := append(append([]readingItem{}, last...), band...)
+			if len(cutItems(last, o.ColumnGap, true)) > 1 && len(cutItems(band, o.ColumnGap, true)) > 1 &&
+				len(cutItems(union, o.ColumnGap, true)) > 1 {
+				merged[len(merged)-1] = union
+				continue
+			}
+		}
+
+		merged = append(merged, band)
+	}
+
+	return merged
+}
+
+// Splits the items at gaps of at least minGap in their projection on the x axis (vertical cut)
+// or the y axis, the parts are ordered from left to right or top to bottom
+func cutItems(items []readingItem, minGap int, vertical bool) [][]readingItem {
+	start := func(rect Rect) int { return rect.Top }
+	end := func(rect Rect) int { return rect.Bottom }
+	if vertical {
+		start = func(rect Rect) int { return rect.Left }
+		end = func(rect Rect) int { return rect.Right }
+	}
+
+	sorted := append([]readingItem{}, items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return start(sorted[i].rect) < start(sorted[j].rect)
+	})
+
+	parts := [][]readingItem{}
+	current := []readingItem{}
+	currentEnd := 0
+	for _, item := range sorted {
+		if len(current) != 0 && start(item.rect)-currentEnd >= minGap {
+			parts = append(parts, current)
+			current = []readingItem{}
+		}
+		if len(current) == 0 || end(item.rect) > currentEnd {
+			currentEnd = end(item.rect)
+		}
+		current = append(current, item)
+	}
+
+	return append(parts, current)
+}
+
+// Groups the items of a block into lines from top to bottom
+func newBlock(items []readingItem) PdfXmlBlock {
+	sorted := append([]readingItem{}, items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].rect.Top == sorted[j].rect.Top {
+			return sorted[i].rect.Left < sorted[j].rect.Left
+		}
+		return sorted[i].rect.Top < sorted[j].rect.Top
+	})
+
+	block := PdfXmlBlock{Rect: sorted[0].rect}
+	lines := [][]readingItem{}
+	lineRect := Rect{}
+	for _, item := range sorted {
+		if len(lines) != 0 && sameLine(lineRect, item.rect) {
+			lines[len(lines)-1] = append(lines[len(lines)-1], item)
+			lineRect = unionRect(lineRect, item.rect)
+			continue
+		}
+
+		lines = append(lines, []readingItem{item})
+		lineRect = item.rect
+	}
+
+	for _, line := range lines {
+		sort.SliceStable(line, func(i, j int) bool {
+			return line[i].rect.Left < line[j].rect.Left
+		})
+
+		pdfLine := PdfXmlLine{Rect: line[0].rect}
+		for _, item := range line {
+			pdfLine.Texts = append(pdfLine.Texts, item.text)
+			pdfLine.Rect = unionRect(pdfLine.Rect, item.rect)
+		}
+		block.Lines = append(block.Lines, pdfLine)
+		block.Rect = unionRect(block.Rect, pdfLine.Rect)
+	}
+
+	return block
+}
+
+func unionRect(a, b Rect) Rect {
+	return Rect{Left: min(a.Left, b.Left), Top: min(a.Top, b.Top), Right: max(a.Right, b.Right), Bottom: max(a.Bottom, b.Bottom)}
+}
+
+// Get the text of the page in reading order. The texts of a line are joined with a space, lines
+// with a line break and blocks and columns are separated by an empty line
+func (p PdfXmlPage) PlainText() string {
+	blocks := []string{}
+	for _, column := range p.ReadingOrder(ReadingOrderOptions{}) {
+		for _, block := range column.Blocks {
+			lines := []string{}
+			for _, line := range block.Lines {
+				lines = append(lines, line.Text())
+			}
+			blocks = append(blocks, strings.Join(lines, "\n"))
+		}
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// Get the content of the texts of the line joined with a space
+func (l PdfXmlLine) Text() string {
+	parts := []string{}
+	for _, text := range l.Texts {
+		if content := strings.TrimSpace(text.Content()); content != "" {
+			parts = append(parts, content)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
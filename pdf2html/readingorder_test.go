@@ -0,0 +1,66 @@
+package pdf2html_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+// Two-column paper with a title over both columns, paragraphs ending at the same height and a footer
+func paperPage() pdf2html.PdfXmlPage {
+	return pdf2html.PdfXmlPage{Texts: []pdf2html.PdfXmlText{
+		textHelperFn(1000, 280, "- 1 -", withWidth(40)),
+		textHelperFn(50, 150, "A study of columns", withWidth(300)),
+		textHelperFn(100, 50, "Left one", withWidth(200)), textHelperFn(100, 320, "Right one", withWidth(200)),
+		textHelperFn(114, 50, "left two", withWidth(180)), textHelperFn(114, 320, "right two", withWidth(200)),
+		textHelperFn(140, 50, "Left paragraph", withWidth(200)), textHelperFn(140, 320, "Right paragraph", withWidth(200)),
+		textHelperFn(154, 50, "with", withWidth(60)), textHelperFn(154, 115, "words", withWidth(60)),
+		{Text: pointerHelperFn("no position")},
+	}}
+}
+
+func TestReadingOrder(t *testing.T) {
+	columns := paperPage().ReadingOrder(pdf2html.ReadingOrderOptions{})
+
+	texts := [][][]string{}
+	for _, column := range columns {
+		blocks := [][]string{}
+		for _, block := range column.Blocks {
+			lines := []string{}
+			for _, line := range block.Lines {
+				lines = append(lines, line.Text())
+			}
+			blocks = append(blocks, lines)
+		}
+		texts = append(texts, blocks)
+	}
+
+	assert.Equal(t, [][][]string{
+		{{"A study of columns"}},
+		{{"Left one", "left two"}, {"Left paragraph", "with words"}},
+		{{"Right one", "right two"}, {"Right paragraph"}},
+		{{"- 1 -"}},
+	}, texts)
+
+	assert.Equal(t, pdf2html.Rect{Left: 50, Top: 100, Right: 250, Bottom: 166}, columns[1].Rect)
+	assert.Equal(t, pdf2html.Rect{Left: 50, Top: 140, Right: 250, Bottom: 166}, columns[1].Blocks[1].Rect)
+	assert.Equal(t, pdf2html.Rect{Left: 50, Top: 154, Right: 175, Bottom: 166}, columns[1].Blocks[1].Lines[1].Rect)
+
+	t.Run("options", func(t *testing.T) {
+		// Without column detection the lines of both columns are read together
+		columns := paperPage().ReadingOrder(pdf2html.ReadingOrderOptions{ColumnGap: 1000, BlockGap: 1000})
+		assert.Len(t, columns, 1)
+		assert.Len(t, columns[0].Blocks, 1)
+		assert.Equal(t, "Left one Right one", columns[0].Blocks[0].Lines[1].Text())
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		assert.Equal(t, []pdf2html.PdfXmlColumn{}, pdf2html.PdfXmlPage{}.ReadingOrder(pdf2html.ReadingOrderOptions{}))
+		assert.Equal(t, "", pdf2html.PdfXmlPage{}.PlainText())
+	})
+}
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "A study of columns\n\nLeft one\nleft two\n\nLeft paragraph\nwith words\n\nRight one\nright two\n\nRight paragraph\n\n- 1 -", paperPage().PlainText())
+}
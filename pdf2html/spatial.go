@@ -0,0 +1,251 @@
+package pdf2html
+
+import (
+	"sort"
+)
+
+// Rectangle on a page, the edges are part of the rectangle
+type Rect struct {
+	Left, Top, Right, Bottom int
+}
+
+// Checks if the rectangles overlap or touch
+func (r Rect) Intersects(o Rect) bool {
+	return r.Left <= o.Right && o.Left <= r.Right && r.Top <= o.Bottom && o.Top <= r.Bottom
+}
+
+// Checks if the rectangle contains the other one completely
+func (r Rect) Contains(o Rect) bool {
+	return r.Left <= o.Left && o.Right <= r.Right && r.Top <= o.Top && o.Bottom <= r.Bottom
+}
+
+// Squared distance between the nearest edges of the rectangles, 0 if they intersect
+func (r Rect) distance2(o Rect) int {
+	dx := max(0, o.Left-r.Right, r.Left-o.Right)
+	dy := max(0, o.Top-r.Bottom, r.Top-o.Bottom)
+	return dx*dx + dy*dy
+}
+
+// Get the box of the text, texts without a size have an empty box at their position
+func (t PdfXmlText) Rect() Rect {
+	rect := Rect{}
+	if t.Left != nil {
+		rect.Left = *t.Left
+	}
+	if t.Top != nil {
+		rect.Top = *t.Top
+	}
+	rect.Right, rect.Bottom = rect.Left, rect.Top
+	if t.Width != nil {
+		rect.Right += *t.Width
+	}
+	if t.Height != nil {
+		rect.Bottom += *t.Height
+	}
+
+	return rect
+}
+
+const minInt int = -maxInt - 1
+
+const spatialCellSize = 50
+
+// Grid of the texts of a page for spatial queries. Every text is added to all cells it covers,
+// so a query only checks the texts of the cells its area covers
+type SpatialIndex struct {
+	texts  []PdfXmlText
+	rects  []Rect
+	bounds Rect             // area of all texts
+	cells  map[[2]int][]int // indexes of the texts by cell
+}
+
+// Creates the spatial index of the texts of the page, texts without a position are not indexed
+func NewSpatialIndex(page PdfXmlPage) *SpatialIndex {
+	index := &SpatialIndex{cells: map[[2]int][]int{}}
+	for _, text := range page.Texts {
+		if text.Top == nil || text.Left == nil {
+			continue
+		}
+
+		i := len(index.texts)
+		rect := text.Rect()
+		index.texts = append(index.texts, text)
+		index.rects = append(index.rects, rect)
+
+		if i == 0 {
+			index.bounds = rect
+		}
+		index.bounds.Left, index.bounds.Top = min(index.bounds.Left, rect.Left), min(index.bounds.Top, rect.Top)
+		index.bounds.Right, index.bounds.Bottom = max(index.bounds.Right, rect.Right), max(index.bounds.Bottom, rect.Bottom)
+
+		index.forCells(rect, func(cell [2]int) {
+			index.cells[cell] = append(index.cells[cell], i)
+		})
+	}
+
+	return index
+}
+
+func (s *SpatialIndex) forCells(rect Rect, fn func(cell [2]int)) {
+	for x := cellOf(rect.Left); x <= cellOf(rect.Right); x++ {
+		for y := cellOf(rect.Top); y <= cellOf(rect.Bottom); y++ {
+			fn([2]int{x, y})
+		}
+	}
+}
+
+func cellOf(position int) int {
+	if position < 0 {
+		return (position+1)/spatialCellSize - 1
+	}
+
+	return position / spatialCellSize
+}
+
+// Get the indexes of the texts that intersect the rectangle in reading order
+func (s *SpatialIndex) query(rect Rect) []int {
+	seen := map[int]bool{}
+	result := []int{}
+	s.forCells(s.clip(rect), func(cell [2]int) {
+		for _, i := range s.cells[cell] {
+			if !seen[i] && s.rects[i].Intersects(rect) {
+				seen[i] = true
+				result = append(result, i)
+			}
+		}
+	})
+
+	sort.Slice(result, func(a, b int) bool {
+		ra, rb := s.rects[result[a]], s.rects[result[b]]
+		if ra.Top == rb.Top {
+			return ra.Left < rb.Left
+		}
+		return ra.Top < rb.Top
+	})
+
+	return result
+}
+
+// Limits the rectangle to the area of the indexed texts, so open areas do not iterate empty cells
+func (s *SpatialIndex) clip(rect Rect) Rect {
+	if len(s.rects) == 0 {
+		return Rect{}
+	}
+
+	return Rect{
+		Left:   max(rect.Left, s.bounds.Left),
+		Top:    max(rect.Top, s.bounds.Top),
+		Right:  min(rect.Right, s.bounds.Right),
+		Bottom: min(rect.Bottom, s.bounds.Bottom),
+	}
+}
+
+func (s *SpatialIndex) textsOf(indexes []int, skip PdfXmlText) []PdfXmlText {
+	skipRect, skipContent := skip.Rect(), skip.Content()
+
+	texts := []PdfXmlText{}
+	for _, i := range indexes {
+		if s.rects[i] == skipRect && s.texts[i].Content() == skipContent {
+			continue
+		}
+		texts = append(texts, s.texts[i])
+	}
+
+	return texts
+}
+
+// Get the texts that intersect the rectangle, from top to bottom and left to right
+func (s *SpatialIndex) TextsIn(rect Rect) []PdfXmlText {
+	texts := []PdfXmlText{}
+	for _, i := range s.query(rect) {
+		texts = append(texts, s.texts[i])
+	}
+
+	return texts
+}
+
+// Get the other texts whose box is at most radius away from the box of the text, nearest first
+func (s *SpatialIndex) TextsNear(text PdfXmlText, radius int) []PdfXmlText {
+	rect := text.Rect()
+	area := Rect{Left: rect.Left - radius, Top: rect.Top - radius, Right: rect.Right + radius, Bottom: rect.Bottom + radius}
+
+	indexes := []int{}
+	for _, i := range s.query(area) {
+		if rect.distance2(s.rects[i]) <= radius*radius {
+			indexes = append(indexes, i)
+		}
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return rect.distance2(s.rects[indexes[a]]) < rect.distance2(s.rects[indexes[b]])
+	})
+
+	return s.textsOf(indexes, text)
+}
+
+// Get the other texts in the line of the text that start at or after its right edge, from left to right
+func (s *SpatialIndex) RightOf(text PdfXmlText) []PdfXmlText {
+	rect := text.Rect()
+
+	indexes := []int{}
+	for _, i := range s.query(Rect{Left: rect.Right, Top: rect.Top, Right: maxInt, Bottom: rect.Bottom}) {
+		if s.rects[i].Left >= rect.Right && sameLine(rect, s.rects[i]) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return s.textsOf(s.byLeft(indexes), text)
+}
+
+// Get the other texts that start at or below the bottom edge of the text and overlap
+// it horizontally, from top to bottom
+func (s *SpatialIndex) Below(text PdfXmlText) []PdfXmlText {
+	rect := text.Rect()
+
+	indexes := []int{}
+	for _, i := range s.query(Rect{Left: rect.Left, Top: rect.Bottom, Right: rect.Right, Bottom: maxInt}) {
+		if s.rects[i].Top >= rect.Bottom {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return s.textsOf(indexes, text)
+}
+
+// Get all texts in the line of the text including itself, from left to right
+func (s *SpatialIndex) LineOf(text PdfXmlText) []PdfXmlText {
+	rect := text.Rect()
+
+	indexes := []int{}
+	for _, i := range s.query(Rect{Left: minInt, Top: rect.Top, Right: maxInt, Bottom: rect.Bottom}) {
+		if sameLine(rect, s.rects[i]) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	texts := []PdfXmlText{}
+	for _, i := range s.byLeft(indexes) {
+		texts = append(texts, s.texts[i])
+	}
+
+	return texts
+}
+
+func (s *SpatialIndex) byLeft(indexes []int) []int {
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return s.rects[indexes[a]].Left < s.rects[indexes[b]].Left
+	})
+
+	return indexes
+}
+
+// Texts are in the same line if they overlap vertically by at least half of the smaller height
+func sameLine(a, b Rect) bool {
+	overlap := min(a.Bottom, b.Bottom) - max(a.Top, b.Top)
+	height := min(a.Bottom-a.Top, b.Bottom-b.Top)
+	if height == 0 {
+		return overlap >= 0
+	}
+
+	return overlap*2 >= height
+}
@@ -0,0 +1,84 @@
+package pdf2html_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+func textContents(texts []pdf2html.PdfXmlText) []string {
+	contents := []string{}
+	for _, text := range texts {
+		contents = append(contents, text.Content())
+	}
+	return contents
+}
+
+// Invoice with a label column and values right of it
+func invoicePage() pdf2html.PdfXmlPage {
+	return pdf2html.PdfXmlPage{Texts: []pdf2html.PdfXmlText{
+		textHelperFn(100, 300, "123.00 EUR", withWidth(80)),
+		textHelperFn(100, 50, "Invoice total:", withWidth(100)),
+		textHelperFn(102, 180, "net", withWidth(60)),
+		textHelperFn(130, 50, "Tax:", withWidth(100)),
+		textHelperFn(130, 300, "23.37 EUR", withWidth(80)),
+		textHelperFn(160, 60, "Due date:", withWidth(60)),
+		textHelperFn(400, 300, "Page 1", withWidth(80)),
+		{Text: pointerHelperFn("no position")},
+	}}
+}
+
+func TestRect(t *testing.T) {
+	rect := pdf2html.Rect{Left: 10, Top: 10, Right: 20, Bottom: 20}
+
+	assert.True(t, rect.Intersects(pdf2html.Rect{Left: 20, Top: 20, Right: 30, Bottom: 30}))
+	assert.False(t, rect.Intersects(pdf2html.Rect{Left: 21, Top: 10, Right: 30, Bottom: 20}))
+	assert.True(t, rect.Contains(pdf2html.Rect{Left: 12, Top: 12, Right: 20, Bottom: 20}))
+	assert.False(t, rect.Contains(pdf2html.Rect{Left: 12, Top: 12, Right: 21, Bottom: 20}))
+
+	assert.Equal(t, pdf2html.Rect{Left: 50, Top: 100, Right: 150, Bottom: 112}, textHelperFn(100, 50, "a", withWidth(100)).Rect())
+	assert.Equal(t, pdf2html.Rect{}, pdf2html.PdfXmlText{}.Rect())
+}
+
+func TestSpatialIndex(t *testing.T) {
+	page := invoicePage()
+	index := pdf2html.NewSpatialIndex(page)
+	label := page.Texts[1]
+
+	t.Run("TextsIn", func(t *testing.T) {
+		assert.Equal(t, []string{"Invoice total:", "123.00 EUR", "net", "Tax:", "23.37 EUR"},
+			textContents(index.TextsIn(pdf2html.Rect{Left: 0, Top: 90, Right: 1000, Bottom: 140})))
+		assert.Empty(t, index.TextsIn(pdf2html.Rect{Left: 500, Top: 0, Right: 600, Bottom: 1000}))
+		assert.Equal(t, []string{"Page 1"}, textContents(index.TextsIn(pdf2html.Rect{Left: -100, Top: 300, Right: 10000, Bottom: 10000})))
+	})
+
+	t.Run("RightOf", func(t *testing.T) {
+		assert.Equal(t, []string{"net", "123.00 EUR"}, textContents(index.RightOf(label)))
+		assert.Equal(t, []string{"23.37 EUR"}, textContents(index.RightOf(page.Texts[3])))
+		assert.Empty(t, index.RightOf(page.Texts[0]))
+	})
+
+	t.Run("Below", func(t *testing.T) {
+		assert.Equal(t, []string{"Tax:", "Due date:"}, textContents(index.Below(label)))
+		assert.Equal(t, []string{"23.37 EUR", "Page 1"}, textContents(index.Below(page.Texts[0])))
+	})
+
+	t.Run("LineOf", func(t *testing.T) {
+		assert.Equal(t, []string{"Invoice total:", "net", "123.00 EUR"}, textContents(index.LineOf(page.Texts[2])))
+		assert.Equal(t, []string{"Page 1"}, textContents(index.LineOf(page.Texts[6])))
+	})
+
+	t.Run("TextsNear", func(t *testing.T) {
+		assert.Equal(t, []string{"Tax:", "net"}, textContents(index.TextsNear(label, 30)))
+		assert.Equal(t, []string{"Tax:", "net", "Due date:"}, textContents(index.TextsNear(label, 50)))
+		assert.Empty(t, index.TextsNear(page.Texts[6], 100))
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		index := pdf2html.NewSpatialIndex(pdf2html.PdfXmlPage{})
+		assert.Empty(t, index.TextsIn(pdf2html.Rect{Right: 100, Bottom: 100}))
+		assert.Empty(t, index.RightOf(label))
+		assert.Equal(t, []string{}, textContents(index.LineOf(label)))
+	})
+}
@@ -0,0 +1,56 @@
+package pdf2html
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// Decodes the pages of a pdftohtml XML one at a time and hands them to fn, so only
+// one page is kept in memory. Decoding stops at the first error of fn or when the
+// context is done
+func DecodeXMLPages(ctx context.Context, reader io.Reader, fn func(page PdfXmlPage) error) error {
+	decoder := xml.NewDecoder(reader)
+
+	for {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "pdf2xml":
+			// Descend into the root element
+		case "page":
+			var page PdfXmlPage
+			err = decoder.DecodeElement(&page, &start)
+			if err != nil {
+				return err
+			}
+
+			err = fn(page)
+			if err != nil {
+				return err
+			}
+		default:
+			err = decoder.Skip()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
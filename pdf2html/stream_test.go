@@ -0,0 +1,81 @@
+package pdf2html_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeXMLPages(t *testing.T) {
+	t.Helper()
+
+	t.Run("Decode all pages", func(t *testing.T) {
+		pages := []pdf2html.PdfXmlPage{}
+		err := pdf2html.DecodeXMLPages(context.Background(), strings.NewReader(xmlContent), func(page pdf2html.PdfXmlPage) error {
+			pages = append(pages, page)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedXMLObj.Pages, pages)
+	})
+
+	t.Run("Outlines are skipped", func(t *testing.T) {
+		content := `<pdf2xml><outline><item page="1">Chapter</item></outline><page number="3"></page></pdf2xml>`
+
+		pages := []pdf2html.PdfXmlPage{}
+		err := pdf2html.DecodeXMLPages(context.Background(), strings.NewReader(content), func(page pdf2html.PdfXmlPage) error {
+			pages = append(pages, page)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(pages))
+		assert.Equal(t, 3, *pages[0].PageNumber)
+	})
+
+	t.Run("Callback error stops decoding", func(t *testing.T) {
+		calls := 0
+		err := pdf2html.DecodeXMLPages(context.Background(), strings.NewReader(xmlContent), func(page pdf2html.PdfXmlPage) error {
+			calls++
+			return fmt.Errorf("callback error")
+		})
+
+		assert.Equal(t, "callback error", err.Error())
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		calls := 0
+		err := pdf2html.DecodeXMLPages(ctx, strings.NewReader(xmlContent), func(page pdf2html.PdfXmlPage) error {
+			calls++
+			cancel()
+			return nil
+		})
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Invalid XML", func(t *testing.T) {
+		err := pdf2html.DecodeXMLPages(context.Background(), strings.NewReader(`<pdf2xml><page number="x"></page></pdf2xml>`), func(page pdf2html.PdfXmlPage) error {
+			return nil
+		})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Unexpected end", func(t *testing.T) {
+		err := pdf2html.DecodeXMLPages(context.Background(), strings.NewReader(`<pdf2xml><outline>`), func(page pdf2html.PdfXmlPage) error {
+			return nil
+		})
+
+		assert.NotNil(t, err)
+	})
+}
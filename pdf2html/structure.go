@@ -0,0 +1,347 @@
+package pdf2html
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type ElementKind string
+
+const (
+	ElementHeading   ElementKind = "heading"
+	ElementParagraph ElementKind = "paragraph"
+	ElementListItem  ElementKind = "listItem"
+	ElementCaption   ElementKind = "caption"
+)
+
+// Heading, paragraph, list item or caption of a document
+type StructuredElement struct {
+	Kind  ElementKind `json:"kind"`
+	Text  string      `json:"text"`            // lines joined with a space
+	Level int         `json:"level,omitempty"` // level of a heading starting at 1
+	Page  int         `json:"page"`
+	Rect  Rect        `json:"rect"`
+}
+
+// Heading with its content and subsections
+type StructuredSection struct {
+	Heading  StructuredElement    `json:"heading"`
+	Elements []StructuredElement  `json:"elements"`
+	Sections []*StructuredSection `json:"sections,omitempty"`
+}
+
+// Document as a tree of sections
+type StructuredDocument struct {
+	Elements []StructuredElement  `json:"elements"` // content before the first heading
+	Sections []*StructuredSection `json:"sections"`
+}
+
+const maxHeadingLength = 150
+
+var (
+	listItemRegexp = regexp.MustCompile(`^([-–•·*▪◦]|\(?[0-9]{1,3}[.)]|\(?[a-zA-Z][.)]|\([ivxIVX]+\))\s+\S`)
+	captionRegexp  = regexp.MustCompile(`^(Figure|Fig\.|Table|Listing|Abbildung|Abb\.|Tabelle)\s*[0-9]+`)
+)
+
+type structureLine struct {
+	line PdfXmlLine
+	text string
+	size int  // largest font size of the line, 0 if unknown
+	bold bool // all texts of the line are bold
+	page int
+}
+
+type headingStyle struct {
+	size int
+	bold bool
+}
+
+// Get the structure of the document. The pages are read in reading order, blocks are split into
+// elements where the font changes, a list item starts or a line is indented. Elements with a larger
+// font than the body text or short bold elements are headings, their level follows from the font
+// size, bold headings below headings of the same size. Elements starting like "Figure 1" are captions.
+// If the document has an outline, elements matching an outline item are headings with the level of
+// the item
+func (d PdfXmlData) Structure() StructuredDocument {
+	elements, _ := d.structure(nil)
+	return newStructuredDocument(elements)
+}
+
+// Get the elements of the document with their lines, the texts for which skip returns true are ignored
+func (d PdfXmlData) structure(skip func(page int, text PdfXmlText) bool) ([]StructuredElement, [][]structureLine) {
+	fonts := fontSpecsByID(d.Pages)
+
+	blocks := [][]structureLine{}
+	for i, page := range d.Pages {
+		number := i + 1
+		if page.PageNumber != nil {
+			number = *page.PageNumber
+		}
+
+		if skip != nil {
+			texts := []PdfXmlText{}
+			for _, text := range page.Texts {
+				if !skip(number, text) {
+					texts = append(texts, text)
+				}
+			}
+			page.Texts = texts
+		}
+
+		for _, column := range page.ReadingOrder(ReadingOrderOptions{}) {
+			for _, block := range column.Blocks {
+				lines := []structureLine{}
+				for _, line := range block.Lines {
+					lines = append(lines, newStructureLine(line, number, fonts))
+				}
+				blocks = append(blocks, lines)
+			}
+		}
+	}
+
+	bodySize, bodyBold := bodyStyle(blocks)
+
+	elements := []StructuredElement{}
+	elementLines := [][]structureLine{}
+	styles := []headingStyle{}
+	for _, block := range blocks {
+		for _, lines := range splitBlock(block, bodySize, bodyBold) {
+			element, style := newElement(lines, bodySize, bodyBold)
+			if element.Kind == ElementHeading {
+				styles = append(styles, style)
+			}
+			elements = append(elements, element)
+			elementLines = append(elementLines, lines)
+		}
+	}
+
+	levels := headingLevels(styles)
+	i := 0
+	for e := range elements {
+		if elements[e].Kind == ElementHeading {
+			elements[e].Level = levels[styles[i]]
+			i++
+		}
+	}
+
+	applyOutline(elements, d.Outlines)
+
+	return elements, elementLines
+}
+
+func newStructureLine(line PdfXmlLine, page int, fonts map[int]PdfXmlFontSpec) structureLine {
+	result := structureLine{line: line, text: line.Text(), page: page, bold: true}
+	for _, text := range line.Texts {
+		if strings.TrimSpace(text.Content()) == "" {
+			continue
+		}
+
+		if text.Font != nil {
+			if fontSpec, ok := fonts[*text.Font]; ok && fontSpec.Size != nil {
+				result.size = max(result.size, *fontSpec.Size)
+			}
+		}
+		result.bold = result.bold && isBoldText(text, fonts)
+	}
+
+	return result
+}
+
+// Get the font size and weight with the most characters
+func bodyStyle(blocks [][]structureLine) (int, bool) {
+	counts := map[headingStyle]int{}
+	for _, block := range blocks {
+		for _, line := range block {
+			counts[headingStyle{size: line.size, bold: line.bold}] += len(line.text)
+		}
+	}
+
+	body, count := headingStyle{}, -1
+	for style, styleCount := range counts {
+		if styleCount > count || (styleCount == count && (style.size < body.size || (style.size == body.size && !style.bold))) {
+			body, count = style, styleCount
+		}
+	}
+
+	return body.size, body.bold
+}
+
+// Splits the lines of a block into the lines of its elements
+func splitBlock(block []structureLine, bodySize int, bodyBold bool) [][]structureLine {
+	left := block[0].line.Rect.Left
+	for _, line := range block {
+		left = min(left, line.line.Rect.Left)
+	}
+
+	elements := [][]structureLine{}
+	for i, line := range block {
+		if i == 0 {
+			elements = append(elements, []structureLine{line})
+			continue
+		}
+
+		current := elements[len(elements)-1]
+		first, previous := current[0], block[i-1]
+		height := line.line.Rect.Bottom - line.line.Rect.Top
+
+		newElement := false
+		switch {
+		case line.size != previous.size || line.bold != previous.bold:
+			// Font changes between a heading and the text, bold words within a paragraph have their own text
+			newElement = isHeadingStyle(line, bodySize, bodyBold) || isHeadingStyle(previous, bodySize, bodyBold)
+		case listItemRegexp.MatchString(line.text):
+			newElement = true
+		case listItemRegexp.MatchString(first.text):
+			// Continuation lines of a list item are indented to its text
+			newElement = line.line.Rect.Left <= first.line.Rect.Left
+		case line.line.Rect.Left-left >= max(1, height/2) && previous.line.Rect.Left-left < max(1, height/2):
+			// Indented first line of a paragraph
+			newElement = true
+		}
+
+		if newElement {
+			elements = append(elements, []structureLine{line})
+		} else {
+			elements[len(elements)-1] = append(current, line)
+		}
+	}
+
+	return elements
+}
+
+func isHeadingStyle(line structureLine, bodySize int, bodyBold bool) bool {
+	return (bodySize != 0 && line.size > bodySize) || (line.bold && !bodyBold && line.size >= bodySize)
+}
+
+func newElement(lines []structureLine, bodySize int, bodyBold bool) (StructuredElement, headingStyle) {
+	parts := []string{}
+	element := StructuredElement{Kind: ElementParagraph, Page: lines[0].page, Rect: lines[0].line.Rect}
+	for _, line := range lines {
+		if line.text != "" {
+			parts = append(parts, line.text)
+		}
+		element.Rect = unionRect(element.Rect, line.line.Rect)
+	}
+	element.Text = strings.Join(parts, " ")
+
+	first := lines[0]
+	style := headingStyle{size: first.size, bold: first.bold}
+	switch {
+	case captionRegexp.MatchString(element.Text):
+		element.Kind = ElementCaption
+	case isHeadingStyle(first, bodySize, bodyBold) && len(element.Text) <= maxHeadingLength &&
+		(first.size > bodySize || (len(lines) <= 2 && !strings.HasSuffix(element.Text, "."))):
+		// Numbered headings like "1. Introduction" are no list items
+		element.Kind = ElementHeading
+	case listItemRegexp.MatchString(element.Text):
+		element.Kind = ElementListItem
+	}
+
+	return element, style
+}
+
+// Get the levels of the heading styles, larger fonts first and bold before regular headings of the same size
+func headingLevels(styles []headingStyle) map[headingStyle]int {
+	distinct := []headingStyle{}
+	seen := map[headingStyle]bool{}
+	for _, style := range styles {
+		if !seen[style] {
+			seen[style] = true
+			distinct = append(distinct, style)
+		}
+	}
+
+	sort.Slice(distinct, func(i, j int) bool {
+		if distinct[i].size == distinct[j].size {
+			return distinct[i].bold && !distinct[j].bold
+		}
+		return distinct[i].size > distinct[j].size
+	})
+
+	levels := map[headingStyle]int{}
+	for i, style := range distinct {
+		levels[style] = i + 1
+	}
+
+	return levels
+}
+
+type outlineEntry struct {
+	page  *int
+	text  string
+	level int
+}
+
+func flattenOutlines(outlines []PdfXmlOutline, level int) []outlineEntry {
+	entries := []outlineEntry{}
+	for _, outline := range outlines {
+		for _, item := range outline.Items {
+			if item.Content != nil && normalizeSpace(*item.Content) != "" {
+				entries = append(entries, outlineEntry{page: item.Page, text: normalizeSpace(*item.Content), level: level})
+			}
+		}
+		entries = append(entries, flattenOutlines(outline.Outlines, level+1)...)
+	}
+
+	return entries
+}
+
+// Marks the elements matching an outline item as headings with the level of the item. Nested
+// outlines are written after the items of their parent, so items are matched regardless of order
+func applyOutline(elements []StructuredElement, outlines []PdfXmlOutline) {
+	entries := flattenOutlines(outlines, 1)
+	used := make([]bool, len(entries))
+
+	for e := range elements {
+		if elements[e].Kind == ElementCaption || len(elements[e].Text) > maxHeadingLength {
+			continue
+		}
+
+		text := normalizeSpace(elements[e].Text)
+		for i, entry := range entries {
+			if used[i] || (entry.page != nil && *entry.page != elements[e].Page) || text != entry.text {
+				continue
+			}
+
+			used[i] = true
+			elements[e].Kind = ElementHeading
+			elements[e].Level = entry.level
+			break
+		}
+	}
+}
+
+func normalizeSpace(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func newStructuredDocument(elements []StructuredElement) StructuredDocument {
+	document := StructuredDocument{Elements: []StructuredElement{}, Sections: []*StructuredSection{}}
+
+	stack := []*StructuredSection{}
+	for _, element := range elements {
+		if element.Kind != ElementHeading {
+			if len(stack) == 0 {
+				document.Elements = append(document.Elements, element)
+			} else {
+				stack[len(stack)-1].Elements = append(stack[len(stack)-1].Elements, element)
+			}
+			continue
+		}
+
+		for len(stack) != 0 && stack[len(stack)-1].Heading.Level >= element.Level {
+			stack = stack[:len(stack)-1]
+		}
+
+		section := &StructuredSection{Heading: element, Elements: []StructuredElement{}}
+		if len(stack) == 0 {
+			document.Sections = append(document.Sections, section)
+		} else {
+			stack[len(stack)-1].Sections = append(stack[len(stack)-1].Sections, section)
+		}
+		stack = append(stack, section)
+	}
+
+	return document
+}
@@ -0,0 +1,119 @@
+package pdf2html_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+func reportData() pdf2html.PdfXmlData {
+	fontSpec := func(id, size int, family string) pdf2html.PdfXmlFontSpec {
+		return pdf2html.PdfXmlFontSpec{ID: pointerHelperFn(id), Size: pointerHelperFn(size), Family: pointerHelperFn(family)}
+	}
+
+	return pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{
+		{
+			PageNumber: pointerHelperFn(1),
+			FontSpecs: []pdf2html.PdfXmlFontSpec{
+				fontSpec(0, 12, "Arial"), fontSpec(1, 18, "Arial"), fontSpec(2, 14, "Arial"), fontSpec(3, 12, "Arial-Bold"),
+			},
+			Texts: []pdf2html.PdfXmlText{
+				textHelperFn(50, 50, "Annual report", withWidth(300), withHeight(20), withFont(1)),
+				textHelperFn(90, 50, "1. Introduction", withWidth(200), withHeight(16), withFont(2)),
+				textHelperFn(120, 50, "This report covers the year.", withWidth(400), withHeight(12), withFont(0)),
+				textHelperFn(134, 50, "It is short.", withWidth(380), withHeight(12), withFont(0)),
+				textHelperFn(148, 70, "An indented paragraph starts here", withWidth(380), withHeight(12), withFont(0)),
+				textHelperFn(162, 50, "and continues.", withWidth(300), withHeight(12), withFont(0)),
+				textHelperFn(190, 50, "- first item", withWidth(200), withHeight(12), withFont(0)),
+				textHelperFn(204, 62, "continued", withWidth(200), withHeight(12), withFont(0)),
+				textHelperFn(218, 50, "- second item", withWidth(200), withHeight(12), withFont(0)),
+				textHelperFn(250, 50, "Figure 1: Revenue", withWidth(200), withHeight(12), withFont(0)),
+				textHelperFn(280, 50, "Details", withWidth(100), withHeight(12), withFont(3)),
+				textHelperFn(294, 50, "Some details.", withWidth(300), withHeight(12), withFont(0)),
+			},
+		},
+		{
+			PageNumber: pointerHelperFn(2),
+			Texts: []pdf2html.PdfXmlText{
+				textHelperFn(50, 50, "2. Results", withWidth(200), withHeight(16), withFont(2)),
+				textHelperFn(80, 50, "Results are good.", withWidth(300), withHeight(12), withFont(0)),
+				textHelperFn(110, 50, "Summary", withWidth(100), withHeight(12), withFont(0)),
+				textHelperFn(140, 50, "All fine.", withWidth(300), withHeight(12), withFont(0)),
+			},
+		},
+	}}
+}
+
+func TestStructure(t *testing.T) {
+	paragraph := func(text string, page int, rect pdf2html.Rect) pdf2html.StructuredElement {
+		return pdf2html.StructuredElement{Kind: pdf2html.ElementParagraph, Text: text, Page: page, Rect: rect}
+	}
+	heading := func(text string, level, page int, rect pdf2html.Rect) pdf2html.StructuredElement {
+		return pdf2html.StructuredElement{Kind: pdf2html.ElementHeading, Text: text, Level: level, Page: page, Rect: rect}
+	}
+
+	introduction := &pdf2html.StructuredSection{
+		Heading: heading("1. Introduction", 2, 1, pdf2html.Rect{Left: 50, Top: 90, Right: 250, Bottom: 106}),
+		Elements: []pdf2html.StructuredElement{
+			paragraph("This report covers the year. It is short.", 1, pdf2html.Rect{Left: 50, Top: 120, Right: 450, Bottom: 146}),
+			paragraph("An indented paragraph starts here and continues.", 1, pdf2html.Rect{Left: 50, Top: 148, Right: 450, Bottom: 174}),
+			{Kind: pdf2html.ElementListItem, Text: "- first item continued", Page: 1, Rect: pdf2html.Rect{Left: 50, Top: 190, Right: 262, Bottom: 216}},
+			{Kind: pdf2html.ElementListItem, Text: "- second item", Page: 1, Rect: pdf2html.Rect{Left: 50, Top: 218, Right: 250, Bottom: 230}},
+			{Kind: pdf2html.ElementCaption, Text: "Figure 1: Revenue", Page: 1, Rect: pdf2html.Rect{Left: 50, Top: 250, Right: 250, Bottom: 262}},
+		},
+		Sections: []*pdf2html.StructuredSection{{
+			Heading:  heading("Details", 3, 1, pdf2html.Rect{Left: 50, Top: 280, Right: 150, Bottom: 292}),
+			Elements: []pdf2html.StructuredElement{paragraph("Some details.", 1, pdf2html.Rect{Left: 50, Top: 294, Right: 350, Bottom: 306})},
+		}},
+	}
+	results := &pdf2html.StructuredSection{
+		Heading: heading("2. Results", 2, 2, pdf2html.Rect{Left: 50, Top: 50, Right: 250, Bottom: 66}),
+		Elements: []pdf2html.StructuredElement{
+			paragraph("Results are good.", 2, pdf2html.Rect{Left: 50, Top: 80, Right: 350, Bottom: 92}),
+			paragraph("Summary", 2, pdf2html.Rect{Left: 50, Top: 110, Right: 150, Bottom: 122}),
+			paragraph("All fine.", 2, pdf2html.Rect{Left: 50, Top: 140, Right: 350, Bottom: 152}),
+		},
+	}
+
+	t.Run("font specs", func(t *testing.T) {
+		assert.Equal(t, pdf2html.StructuredDocument{
+			Elements: []pdf2html.StructuredElement{},
+			Sections: []*pdf2html.StructuredSection{{
+				Heading:  heading("Annual report", 1, 1, pdf2html.Rect{Left: 50, Top: 50, Right: 350, Bottom: 70}),
+				Elements: []pdf2html.StructuredElement{},
+				Sections: []*pdf2html.StructuredSection{introduction, results},
+			}},
+		}, reportData().Structure())
+	})
+
+	t.Run("outline", func(t *testing.T) {
+		data := reportData()
+		data.Outlines = []pdf2html.PdfXmlOutline{{
+			Items: []pdf2html.PdfXmlOutlineItem{{Page: pointerHelperFn(1), Content: pointerHelperFn("Annual  Report")}},
+			Outlines: []pdf2html.PdfXmlOutline{{Items: []pdf2html.PdfXmlOutlineItem{
+				{Page: pointerHelperFn(1), Content: pointerHelperFn("1. Introduction")},
+				{Page: pointerHelperFn(2), Content: pointerHelperFn("2. Results")},
+				{Page: pointerHelperFn(2), Content: pointerHelperFn("Summary")},
+				{Page: pointerHelperFn(3), Content: pointerHelperFn("Appendix")},
+			}}},
+		}}
+
+		document := data.Structure()
+		assert.Len(t, document.Sections, 1)
+		sections := document.Sections[0].Sections
+		assert.Len(t, sections, 3)
+		assert.Equal(t, introduction, sections[0])
+		assert.Len(t, sections[1].Elements, 1)
+		assert.Equal(t, heading("Summary", 2, 2, pdf2html.Rect{Left: 50, Top: 110, Right: 150, Bottom: 122}), sections[2].Heading)
+		assert.Equal(t, []pdf2html.StructuredElement{paragraph("All fine.", 2, pdf2html.Rect{Left: 50, Top: 140, Right: 350, Bottom: 152})}, sections[2].Elements)
+	})
+
+	t.Run("without headings", func(t *testing.T) {
+		data := pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{{Texts: []pdf2html.PdfXmlText{textHelperFn(50, 50, "Only text", withWidth(100))}}}}
+		assert.Equal(t, pdf2html.StructuredDocument{
+			Elements: []pdf2html.StructuredElement{paragraph("Only text", 1, pdf2html.Rect{Left: 50, Top: 50, Right: 150, Bottom: 62})},
+			Sections: []*pdf2html.StructuredSection{},
+		}, data.Structure())
+	})
+}
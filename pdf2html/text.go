@@ -0,0 +1,178 @@
+package pdf2html
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Decodes a <text> element. Text and BoldText are filled like the plain xml tags would do,
+// additionally all inline content is kept in order in Runs
+func (t *PdfXmlText) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*t = PdfXmlText{XMLName: start.Name}
+
+	for _, attr := range start.Attr {
+		var target **int
+		switch attr.Name.Local {
+		case "top":
+			target = &t.Top
+		case "left":
+			target = &t.Left
+		case "width":
+			target = &t.Width
+		case "height":
+			target = &t.Height
+		case "font":
+			target = &t.Font
+		default:
+			continue
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(attr.Value))
+		if err != nil {
+			return fmt.Errorf("invalid attribute %s: %w", attr.Name.Local, err)
+		}
+		*target = &value
+	}
+
+	text := ""
+	var boldText *string
+
+	// Open inline elements, the formatting of a run is the combination of all of them
+	stack := []xml.StartElement{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, token)
+			if len(stack) == 1 && token.Name.Local == "b" {
+				boldText = new(string)
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				t.Text = &text
+				t.BoldText = boldText
+				return nil
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) == 0 {
+				text += string(token)
+			} else if len(stack) == 1 && stack[0].Name.Local == "b" {
+				*boldText += string(token)
+			}
+
+			t.addRun(string(token), stack)
+		}
+	}
+}
+
+func (t *PdfXmlText) addRun(content string, stack []xml.StartElement) {
+	if content == "" {
+		return
+	}
+
+	run := PdfXmlRun{Text: content}
+	for _, element := range stack {
+		switch element.Name.Local {
+		case "b":
+			run.Bold = true
+		case "i":
+			run.Italic = true
+		case "a":
+			for _, attr := range element.Attr {
+				if attr.Name.Local == "href" {
+					href := attr.Value
+					run.Href = &href
+				}
+			}
+		}
+	}
+
+	// Merge with the previous run if the formatting is the same
+	if len(t.Runs) != 0 {
+		last := &t.Runs[len(t.Runs)-1]
+		if last.Bold == run.Bold && last.Italic == run.Italic && equalHref(last.Href, run.Href) {
+			last.Text += run.Text
+			return
+		}
+	}
+
+	t.Runs = append(t.Runs, run)
+}
+
+func equalHref(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// Get the full content of the text in order. Texts without runs fall back to
+// the bold text followed by the normal text
+func (t PdfXmlText) Content() string {
+	return content(t.Runs, t.BoldText, t.Text)
+}
+
+// Get the full content of the table cell in order, the lines of a cell
+// with several texts are joined with a space
+func (c PdfXmlTableEntryContent) Content() string {
+	if len(c.Lines) > 1 {
+		parts := []string{}
+		for _, line := range c.Lines {
+			parts = append(parts, strings.TrimSpace(line.Content()))
+		}
+		return strings.Join(parts, " ")
+	}
+
+	return content(c.Runs, c.BoldText, c.Text)
+}
+
+func content(runs []PdfXmlRun, boldText, text *string) string {
+	if len(runs) != 0 {
+		var builder strings.Builder
+		for _, run := range runs {
+			builder.WriteString(run.Text)
+		}
+		return builder.String()
+	}
+
+	result := ""
+	if boldText != nil {
+		result += *boldText
+	}
+	if text != nil {
+		result += *text
+	}
+
+	return result
+}
+
+// Get the font specification for an id. pdftohtml writes a fontspec only on the
+// first page using it, therefore all pages are searched
+func (d PdfXmlData) FontSpec(id int) *PdfXmlFontSpec {
+	for _, page := range d.Pages {
+		for _, fontSpec := range page.FontSpecs {
+			if fontSpec.ID != nil && *fontSpec.ID == id {
+				return &fontSpec
+			}
+		}
+	}
+
+	return nil
+}
+
+// Get the font specification of a text, nil if the text has no known font
+func (d PdfXmlData) TextFontSpec(text PdfXmlText) *PdfXmlFontSpec {
+	if text.Font == nil {
+		return nil
+	}
+
+	return d.FontSpec(*text.Font)
+}
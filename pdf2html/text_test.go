@@ -0,0 +1,129 @@
+package pdf2html_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+var richXmlContent = `<?xml version="1.0" encoding="UTF-8"?>
+<pdf2xml producer="poppler" version="24.11.0">
+<page number="1" position="absolute" top="0" left="0" height="1262" width="892">
+	<fontspec id="0" size="8" family="ArialMT" color="#000000"/>
+	<fontspec id="1" size="12" family="Arial" color="#AAAAAA"/>
+	<image top="10" left="20" width="100" height="50" src="document-1_1.png"/>
+	<text top="100" left="106" width="227" height="19" font="1">Test <b>bold</b> mixed</text>
+	<text top="120" left="106" width="227" height="19" font="0"><i>Italic</i> and <a href="https://example.com"><b>link</b> text</a></text>
+	<text top="140" left="106" width="227" height="19" font="0"><i><b>Both</b></i><b> first</b><b> last</b></text>
+</page>
+<page number="2" position="absolute" top="0" left="0" height="1262" width="892">
+	<text top="100" left="106" width="227" height="19" font="1">Font of page 1</text>
+</page>
+</pdf2xml>`
+
+func TestRichText(t *testing.T) {
+	t.Helper()
+
+	var data pdf2html.PdfXmlData
+	err := xml.Unmarshal([]byte(richXmlContent), &data)
+	assert.Nil(t, err)
+
+	t.Run("Runs keep the order and formatting", func(t *testing.T) {
+		texts := data.Pages[0].Texts
+
+		assert.Equal(t, []pdf2html.PdfXmlRun{
+			{Text: "Test "},
+			{Text: "bold", Bold: true},
+			{Text: " mixed"},
+		}, texts[0].Runs)
+		assert.Equal(t, "Test bold mixed", texts[0].Content())
+
+		assert.Equal(t, []pdf2html.PdfXmlRun{
+			{Text: "Italic", Italic: true},
+			{Text: " and "},
+			{Text: "link", Bold: true, Href: pointerHelperFn("https://example.com")},
+			{Text: " text", Href: pointerHelperFn("https://example.com")},
+		}, texts[1].Runs)
+		assert.Equal(t, "Italic and link text", texts[1].Content())
+
+		assert.Equal(t, []pdf2html.PdfXmlRun{
+			{Text: "Both", Bold: true, Italic: true},
+			{Text: " first last", Bold: true},
+		}, texts[2].Runs)
+	})
+
+	t.Run("Text and bold text stay compatible", func(t *testing.T) {
+		texts := data.Pages[0].Texts
+
+		assert.Equal(t, "Test  mixed", *texts[0].Text)
+		assert.Equal(t, "bold", *texts[0].BoldText)
+
+		assert.Equal(t, " and ", *texts[1].Text)
+		assert.Nil(t, texts[1].BoldText)
+
+		assert.Equal(t, "", *texts[2].Text)
+		assert.Equal(t, " last", *texts[2].BoldText)
+	})
+
+	t.Run("Font resolution", func(t *testing.T) {
+		assert.Equal(t, 1, *data.Pages[0].Texts[0].Font)
+
+		fontSpec := data.TextFontSpec(data.Pages[1].Texts[0])
+		assert.Equal(t, 12, *fontSpec.Size)
+		assert.Equal(t, "Arial", *fontSpec.Family)
+
+		assert.Nil(t, data.FontSpec(5))
+		assert.Nil(t, data.TextFontSpec(pdf2html.PdfXmlText{}))
+	})
+
+	t.Run("Images", func(t *testing.T) {
+		assert.Equal(t, []pdf2html.PdfXmlImage{
+			{
+				XMLName: xml.Name{Local: "image"},
+				Top:     pointerHelperFn(10),
+				Left:    pointerHelperFn(20),
+				Width:   pointerHelperFn(100),
+				Height:  pointerHelperFn(50),
+				Src:     pointerHelperFn("document-1_1.png"),
+			},
+		}, data.Pages[0].Images)
+	})
+
+	t.Run("Table content keeps runs and font", func(t *testing.T) {
+		table := data.Pages[0].ExtractTableContent(pdf2html.PdfXmlTableOption{
+			From:                  0,
+			To:                    200,
+			Columns:               1,
+			GetColumnFunc:         pdf2html.GetColumnCalculationWithVariance([]int{106}, 10),
+			AllowedHeightVariance: 5,
+		})
+
+		assert.Equal(t, 3, len(table))
+		assert.Equal(t, "Italic and link text", table[1].Content[0].Content())
+		assert.Equal(t, data.Pages[0].Texts[1].Runs, table[1].Content[0].Runs)
+		assert.Equal(t, 0, *table[1].Content[0].Font)
+	})
+
+	t.Run("Content without runs", func(t *testing.T) {
+		text := pdf2html.PdfXmlText{
+			Text:     pointerHelperFn(" text"),
+			BoldText: pointerHelperFn("Bold"),
+		}
+		assert.Equal(t, "Bold text", text.Content())
+		assert.Equal(t, "", pdf2html.PdfXmlTableEntryContent{}.Content())
+	})
+
+	t.Run("Invalid attribute", func(t *testing.T) {
+		var text pdf2html.PdfXmlText
+		err := xml.Unmarshal([]byte(`<text top="abc"></text>`), &text)
+		assert.Equal(t, `invalid attribute top: strconv.Atoi: parsing "abc": invalid syntax`, err.Error())
+	})
+
+	t.Run("Unexpected end", func(t *testing.T) {
+		var text pdf2html.PdfXmlText
+		err := xml.Unmarshal([]byte(`<text top="1"><b>bold`), &text)
+		assert.NotNil(t, err)
+	})
+}
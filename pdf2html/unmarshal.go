@@ -0,0 +1,511 @@
+package pdf2html
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type UnmarshalOptions struct {
+	Header             bool                    // the first entry is the header row, used for the header tags and not unmarshalled
+	DecimalSeparator   rune                    // decimal separator of numbers (default '.')
+	ThousandsSeparator rune                    // thousands separator of numbers (default ',')
+	Location           *time.Location          // location of dates without a zone (default UTC)
+	MonthNames         map[string]time.Month   // localized month names of dates, e.g. "März" or "Mär", matched case insensitive
+	DayNames           map[string]time.Weekday // localized weekday names of dates, e.g. "Montag" or "Mo", matched case insensitive
+}
+
+// Error of a single field of a table row
+type UnmarshalRowError struct {
+	Row    int    // index of the entry
+	Page   int    // page of the entry
+	Field  string // name of the struct field
+	Column int    // column of the field
+	Err    error
+}
+
+func (e UnmarshalRowError) Error() string {
+	return fmt.Sprintf("row %d (page %d), field %s (column %d): %s", e.Row, e.Page, e.Field, e.Column, e.Err)
+}
+
+func (e UnmarshalRowError) Unwrap() error {
+	return e.Err
+}
+
+// Errors of all rows that cannot be unmarshalled
+type UnmarshalErrors []UnmarshalRowError
+
+func (e UnmarshalErrors) Error() string {
+	messages := []string{}
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (o UnmarshalOptions) withDefaults() UnmarshalOptions {
+	if o.DecimalSeparator == 0 {
+		o.DecimalSeparator = '.'
+	}
+	if o.ThousandsSeparator == 0 {
+		o.ThousandsSeparator = ','
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+
+	return o
+}
+
+const tableTag = "pdftable"
+
+type tableField struct {
+	index    int
+	name     string
+	column   int
+	header   string
+	format   string
+	layout   string
+	bold     bool
+	plain    bool
+	required bool
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+	currencyPrefixRegexp = regexp.MustCompile(`^(\p{Sc}|[A-Z]{3}\b)`)
+	currencySuffixRegexp = regexp.MustCompile(`(\p{Sc}|\b[A-Z]{3})$`)
+	wordRegexp           = regexp.MustCompile(`\p{L}+`)
+)
+
+// Unmarshals the table entries into out, a pointer to a slice of structs. The fields are mapped with
+// the pdftable tag, e.g. `pdftable:"col=2,format=decimal"` or `pdftable:"header=Date,layout=02.01.2006"`:
+//
+//	col=n        column of the field (starting at 0)
+//	header=name  column with this header text, requires the Header option
+//	format=x     decimal (numbers with the separators of the options) or date (requires a layout)
+//	layout=x     layout of a date for time.Parse, names of the MonthNames and DayNames options are
+//	             replaced by the English names before parsing
+//	bold, plain  only the bold or the not bold text of the cell
+//	required     an empty cell is an error, otherwise the field keeps its zero value
+//
+// Rows with errors are not added to out, their errors are returned as UnmarshalErrors
+func UnmarshalTable(entries []*PdfXmlTableEntry, out any, options UnmarshalOptions) error {
+	options = options.withDefaults()
+
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("out has to be a pointer to a slice")
+	}
+
+	sliceType := target.Elem().Type()
+	structType := sliceType.Elem()
+	isPointer := structType.Kind() == reflect.Pointer
+	if isPointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("out has to be a pointer to a slice of structs")
+	}
+
+	fields, err := parseTableFields(structType)
+	if err != nil {
+		return err
+	}
+
+	if options.Header {
+		if len(entries) == 0 {
+			return fmt.Errorf("missing header row")
+		}
+
+		err = resolveHeaders(fields, entries[0])
+		if err != nil {
+			return err
+		}
+		entries = entries[1:]
+	}
+
+	for _, field := range fields {
+		if field.column == -1 {
+			return fmt.Errorf("header %q of field %s requires the Header option", field.header, field.name)
+		}
+	}
+
+	rowOffset := 0
+	if options.Header {
+		rowOffset = 1
+	}
+
+	result := reflect.MakeSlice(sliceType, 0, len(entries))
+	errs := UnmarshalErrors{}
+	for i, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		row := reflect.New(structType)
+		rowErrs := UnmarshalErrors{}
+		for _, field := range fields {
+			var content *PdfXmlTableEntryContent
+			if field.column < len(entry.Content) {
+				content = entry.Content[field.column]
+			}
+
+			err := setTableValue(row.Elem().Field(field.index), cellValue(content, field), field, options)
+			if err != nil {
+				rowErrs = append(rowErrs, UnmarshalRowError{
+					Row:    i + rowOffset,
+					Page:   entry.Page,
+					Field:  field.name,
+					Column: field.column,
+					Err:    err,
+				})
+			}
+		}
+
+		if len(rowErrs) != 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+
+		if isPointer {
+			result = reflect.Append(result, row)
+		} else {
+			result = reflect.Append(result, row.Elem())
+		}
+	}
+
+	target.Elem().Set(result)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func parseTableFields(structType reflect.Type) ([]*tableField, error) {
+	fields := []*tableField{}
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		tag, ok := structField.Tag.Lookup(tableTag)
+		if !ok || tag == "-" || !structField.IsExported() {
+			continue
+		}
+
+		field := &tableField{index: i, name: structField.Name, column: -1}
+
+		// Values can contain commas, e.g. the date layout "Jan 2, 2006"
+		parts := []string{}
+		for _, part := range strings.Split(tag, ",") {
+			if len(parts) != 0 && !strings.Contains(part, "=") && !isTableFlag(part) {
+				parts[len(parts)-1] += "," + part
+				continue
+			}
+			parts = append(parts, part)
+		}
+
+		for _, part := range parts {
+			key, value, _ := strings.Cut(part, "=")
+			switch strings.TrimSpace(key) {
+			case "col":
+				column, err := strconv.Atoi(value)
+				if err != nil || column < 0 {
+					return nil, fmt.Errorf("invalid column %q of field %s", value, field.name)
+				}
+				field.column = column
+			case "header":
+				field.header = value
+			case "format":
+				if value != "decimal" && value != "date" {
+					return nil, fmt.Errorf("unknown format %q of field %s", value, field.name)
+				}
+				field.format = value
+			case "layout":
+				field.layout = value
+			case "bold":
+				field.bold = true
+			case "plain":
+				field.plain = true
+			case "required":
+				field.required = true
+			default:
+				return nil, fmt.Errorf("unknown option %q of field %s", key, field.name)
+			}
+		}
+
+		if field.column == -1 && field.header == "" {
+			return nil, fmt.Errorf("missing col or header of field %s", field.name)
+		}
+		if field.format == "date" && field.layout == "" {
+			return nil, fmt.Errorf("missing layout of field %s", field.name)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func isTableFlag(part string) bool {
+	switch strings.TrimSpace(part) {
+	case "bold", "plain", "required":
+		return true
+	}
+
+	return false
+}
+
+// Maps the header tags to the columns of the header row
+func resolveHeaders(fields []*tableField, header *PdfXmlTableEntry) error {
+	for _, field := range fields {
+		if field.header == "" || field.column != -1 {
+			continue
+		}
+
+		if header != nil {
+			for column, content := range header.Content {
+				if content != nil && strings.EqualFold(strings.TrimSpace(content.Content()), strings.TrimSpace(field.header)) {
+					field.column = column
+					break
+				}
+			}
+		}
+
+		if field.column == -1 {
+			return fmt.Errorf("cannot find header %q of field %s", field.header, field.name)
+		}
+	}
+
+	return nil
+}
+
+// Get the text of the cell for the field
+func cellValue(content *PdfXmlTableEntryContent, field *tableField) string {
+	if content == nil {
+		return ""
+	}
+	if !field.bold && !field.plain {
+		return strings.TrimSpace(content.Content())
+	}
+
+	lines := content.Lines
+	if len(lines) == 0 {
+		lines = []PdfXmlText{{Text: content.Text, BoldText: content.BoldText, Runs: content.Runs}}
+	}
+
+	parts := []string{}
+	for _, line := range lines {
+		part := lineText(line, field.bold)
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func setTableValue(value reflect.Value, raw string, field *tableField, options UnmarshalOptions) error {
+	if raw == "" {
+		if field.required {
+			return fmt.Errorf("missing value")
+		}
+		return nil
+	}
+
+	if value.Kind() == reflect.Pointer {
+		elem := reflect.New(value.Type().Elem())
+		err := setTableValue(elem.Elem(), raw, field, options)
+		if err != nil {
+			return err
+		}
+		value.Set(elem)
+		return nil
+	}
+
+	if value.Type() == timeType {
+		if field.layout == "" {
+			return fmt.Errorf("missing layout")
+		}
+
+		date, err := time.ParseInLocation(field.layout, translateDateNames(raw, field.layout, options), options.Location)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(date))
+		return nil
+	}
+
+	if value.Addr().Type().Implements(textUnmarshalerType) {
+		if field.format == "decimal" {
+			number, err := normalizeDecimal(raw, options)
+			if err != nil {
+				return err
+			}
+			raw = number
+		}
+
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		if field.format == "decimal" {
+			number, err := normalizeDecimal(raw, options)
+			if err != nil {
+				return err
+			}
+			raw = number
+		}
+		value.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := normalizeDecimal(raw, options)
+		if err != nil {
+			return err
+		}
+		v, err := strconv.ParseInt(number, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := normalizeDecimal(raw, options)
+		if err != nil {
+			return err
+		}
+		v, err := strconv.ParseUint(number, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		number, err := normalizeDecimal(raw, options)
+		if err != nil {
+			return err
+		}
+		v, err := strconv.ParseFloat(number, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(v)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(v)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+
+	return nil
+}
+
+// Get the date with the localized month and weekday names of the options replaced by the English
+// names, the full names if the layout contains them and the abbreviations otherwise
+func translateDateNames(raw string, layout string, options UnmarshalOptions) string {
+	if len(options.MonthNames) == 0 && len(options.DayNames) == 0 {
+		return raw
+	}
+
+	months := map[string]time.Month{}
+	for name, month := range options.MonthNames {
+		months[strings.ToLower(name)] = month
+	}
+	days := map[string]time.Weekday{}
+	for name, day := range options.DayNames {
+		days[strings.ToLower(name)] = day
+	}
+
+	fullMonth := strings.Contains(layout, "January")
+	fullDay := strings.Contains(layout, "Monday")
+
+	return wordRegexp.ReplaceAllStringFunc(raw, func(word string) string {
+		if month, ok := months[strings.ToLower(word)]; ok {
+			if fullMonth {
+				return month.String()
+			}
+			return month.String()[:3]
+		}
+		if day, ok := days[strings.ToLower(word)]; ok {
+			if fullDay {
+				return day.String()
+			}
+			return day.String()[:3]
+		}
+
+		return word
+	})
+}
+
+// Get the number in the format of strconv with the separators of the options, e.g. "-1234.50" for "1.234,50-"
+func NormalizeNumber(raw string, options UnmarshalOptions) (string, error) {
+	return normalizeDecimal(raw, options.withDefaults())
+}
+
+// Get the number in the format of strconv. Thousands separators, spaces and currency symbols or
+// codes at the start or the end are removed. A leading or trailing minus or enclosing parentheses
+// mark negative numbers, e.g. "1.234,50-" or "(12,00) EUR", other signs and letters are invalid
+func normalizeDecimal(raw string, options UnmarshalOptions) (string, error) {
+	negative := false
+	content := trimCurrency(raw)
+	if strings.HasPrefix(content, "(") && strings.HasSuffix(content, ")") {
+		negative = true
+		content = trimCurrency(content[1 : len(content)-1])
+	}
+
+	for _, sign := range []string{"-", "+"} {
+		switch {
+		case strings.HasPrefix(content, sign):
+			content = trimCurrency(content[1:])
+		case strings.HasSuffix(content, sign):
+			content = trimCurrency(content[:len(content)-1])
+		default:
+			continue
+		}
+
+		negative = negative != (sign == "-")
+		break
+	}
+
+	var builder strings.Builder
+	digits, decimals := 0, 0
+	for _, r := range content {
+		switch {
+		case unicode.IsDigit(r):
+			builder.WriteRune(r)
+			digits++
+		case r == options.DecimalSeparator:
+			builder.WriteRune('.')
+			decimals++
+		case r == options.ThousandsSeparator || unicode.IsSpace(r):
+		default:
+			return "", fmt.Errorf("invalid number %q", raw)
+		}
+	}
+
+	if digits == 0 || decimals > 1 {
+		return "", fmt.Errorf("invalid number %q", raw)
+	}
+
+	number := builder.String()
+	if negative {
+		number = "-" + number
+	}
+
+	return number, nil
+}
+
+// Get the content without spaces and a currency symbol or code at the start and the end
+func trimCurrency(content string) string {
+	content = currencyPrefixRegexp.ReplaceAllString(strings.TrimSpace(content), "")
+	return strings.TrimSpace(currencySuffixRegexp.ReplaceAllString(content, ""))
+}
@@ -0,0 +1,229 @@
+package pdf2html_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/stretchr/testify/assert"
+)
+
+func tableCell(content string) *pdf2html.PdfXmlTableEntryContent {
+	return &pdf2html.PdfXmlTableEntryContent{Text: pointerHelperFn(content)}
+}
+
+func tableEntry(page int, cells ...string) *pdf2html.PdfXmlTableEntry {
+	entry := &pdf2html.PdfXmlTableEntry{Page: page}
+	for _, cell := range cells {
+		entry.Content = append(entry.Content, tableCell(cell))
+	}
+	return entry
+}
+
+type transaction struct {
+	Date        time.Time `pdftable:"col=0,layout=02.01.2006"`
+	Description string    `pdftable:"col=1"`
+	Amount      float64   `pdftable:"col=2,format=decimal"`
+	Note        *string   `pdftable:"col=3"`
+	Ignored     string
+}
+
+func TestUnmarshalTable(t *testing.T) {
+	germanOptions := pdf2html.UnmarshalOptions{DecimalSeparator: ',', ThousandsSeparator: '.'}
+
+	t.Run("columns and locale", func(t *testing.T) {
+		entries := []*pdf2html.PdfXmlTableEntry{
+			tableEntry(1, "01.02.2024", "Salary", "1.234,50"),
+			tableEntry(1, "02.02.2024", "Rent", "800,00-", "monthly"),
+			nil,
+		}
+
+		result := []transaction{}
+		err := pdf2html.UnmarshalTable(entries, &result, germanOptions)
+		assert.Nil(t, err)
+		assert.Equal(t, []transaction{
+			{Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Description: "Salary", Amount: 1234.5},
+			{Date: time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC), Description: "Rent", Amount: -800, Note: pointerHelperFn("monthly")},
+		}, result)
+	})
+
+	t.Run("header names", func(t *testing.T) {
+		type row struct {
+			Amount int    `pdftable:"header=amount"`
+			Name   string `pdftable:"header=Name,required"`
+		}
+
+		entries := []*pdf2html.PdfXmlTableEntry{
+			tableEntry(1, "Name", "Amount"),
+			tableEntry(1, "A", "1,000"),
+			tableEntry(2, "B", "(20)"),
+		}
+
+		result := []*row{}
+		err := pdf2html.UnmarshalTable(entries, &result, pdf2html.UnmarshalOptions{Header: true})
+		assert.Nil(t, err)
+		assert.Equal(t, []*row{{Amount: 1000, Name: "A"}, {Amount: -20, Name: "B"}}, result)
+
+		err = pdf2html.UnmarshalTable(entries, &result, pdf2html.UnmarshalOptions{})
+		assert.EqualError(t, err, `header "amount" of field Amount requires the Header option`)
+
+		type missing struct {
+			Total int `pdftable:"header=Total"`
+		}
+		err = pdf2html.UnmarshalTable(entries, &[]missing{}, pdf2html.UnmarshalOptions{Header: true})
+		assert.EqualError(t, err, `cannot find header "Total" of field Total`)
+	})
+
+	t.Run("bold and plain text", func(t *testing.T) {
+		type row struct {
+			Title string `pdftable:"col=0,bold"`
+			Body  string `pdftable:"col=0,plain"`
+		}
+
+		entries := []*pdf2html.PdfXmlTableEntry{
+			{Content: []*pdf2html.PdfXmlTableEntryContent{{
+				Runs: []pdf2html.PdfXmlRun{{Text: "Fee ", Bold: true}, {Text: "for March"}},
+			}}},
+			{Content: []*pdf2html.PdfXmlTableEntryContent{{
+				Text: pointerHelperFn("Transfer"), BoldText: pointerHelperFn("Bank"),
+			}}},
+		}
+
+		result := []row{}
+		err := pdf2html.UnmarshalTable(entries, &result, pdf2html.UnmarshalOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, []row{{Title: "Fee", Body: "for March"}, {Title: "Bank", Body: "Transfer"}}, result)
+	})
+
+	t.Run("row errors", func(t *testing.T) {
+		entries := []*pdf2html.PdfXmlTableEntry{
+			tableEntry(1, "01.02.2024", "Salary", "1.234,50"),
+			tableEntry(2, "2024-02-02", "Rent", "abc?"),
+			tableEntry(2, "03.02.2024", "Fee"),
+		}
+
+		result := []transaction{}
+		err := pdf2html.UnmarshalTable(entries, &result, germanOptions)
+		assert.Len(t, result, 2)
+
+		var rowErrors pdf2html.UnmarshalErrors
+		assert.True(t, errors.As(err, &rowErrors))
+		assert.Len(t, rowErrors, 2)
+		assert.Equal(t, 1, rowErrors[0].Row)
+		assert.Equal(t, 2, rowErrors[0].Page)
+		assert.Equal(t, "Date", rowErrors[0].Field)
+		assert.Equal(t, "Amount", rowErrors[1].Field)
+		assert.EqualError(t, rowErrors[1], `row 1 (page 2), field Amount (column 2): invalid number "abc?"`)
+	})
+
+	t.Run("signs, letters and currencies", func(t *testing.T) {
+		type row struct {
+			Amount float64 `pdftable:"col=0"`
+		}
+
+		for raw, expected := range map[string]float64{
+			"-12.50":     -12.5,
+			"12.50-":     -12.5,
+			"(12.50)":    -12.5,
+			"+7":         7,
+			"EUR -12.50": -12.5,
+			"-€ 7":       -7,
+			"(12.00) $":  -12,
+			"1 234.50":   1234.5,
+		} {
+			result := []row{}
+			err := pdf2html.UnmarshalTable([]*pdf2html.PdfXmlTableEntry{tableEntry(1, raw)}, &result, pdf2html.UnmarshalOptions{})
+			assert.Nil(t, err, raw)
+			assert.Equal(t, []row{{Amount: expected}}, result, raw)
+		}
+
+		for _, raw := range []string{"2024-01-02", "12-34", "1(2)", "--12", "12a", "n/a", "12 pcs", "€"} {
+			result := []row{}
+			err := pdf2html.UnmarshalTable([]*pdf2html.PdfXmlTableEntry{tableEntry(1, raw)}, &result, pdf2html.UnmarshalOptions{})
+			assert.EqualError(t, err, fmt.Sprintf(`row 0 (page 1), field Amount (column 0): invalid number %q`, raw))
+			assert.Empty(t, result)
+		}
+	})
+
+	t.Run("required and missing cells", func(t *testing.T) {
+		type row struct {
+			Amount float64 `pdftable:"col=5,required"`
+		}
+
+		result := []row{}
+		err := pdf2html.UnmarshalTable([]*pdf2html.PdfXmlTableEntry{tableEntry(1, "a")}, &result, pdf2html.UnmarshalOptions{})
+		assert.EqualError(t, err, "row 0 (page 1), field Amount (column 5): missing value")
+		assert.Empty(t, result)
+	})
+
+	t.Run("layouts with commas", func(t *testing.T) {
+		type row struct {
+			Date time.Time `pdftable:"col=0,layout=Jan 2, 2006,required"`
+		}
+
+		result := []row{}
+		err := pdf2html.UnmarshalTable([]*pdf2html.PdfXmlTableEntry{tableEntry(1, "Feb 3, 2024")}, &result, pdf2html.UnmarshalOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, []row{{Date: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)}}, result)
+	})
+
+	t.Run("localized month and weekday names", func(t *testing.T) {
+		type row struct {
+			Long  time.Time `pdftable:"col=0,layout=2. January 2006"`
+			Short time.Time `pdftable:"col=1,layout=Mon, 2. Jan 2006"`
+		}
+
+		entries := []*pdf2html.PdfXmlTableEntry{tableEntry(1, "3. März 2024", "Mo, 4. mär 2024")}
+
+		result := []row{}
+		err := pdf2html.UnmarshalTable(entries, &result, pdf2html.UnmarshalOptions{})
+		assert.ErrorContains(t, err, "field Long (column 0): parsing time")
+		assert.Empty(t, result)
+
+		err = pdf2html.UnmarshalTable(entries, &result, pdf2html.UnmarshalOptions{
+			MonthNames: map[string]time.Month{"März": time.March, "Mär": time.March},
+			DayNames:   map[string]time.Weekday{"Mo": time.Monday},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []row{{
+			Long:  time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+			Short: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		}}, result)
+	})
+
+	t.Run("invalid targets", func(t *testing.T) {
+		assert.EqualError(t, pdf2html.UnmarshalTable(nil, []transaction{}, pdf2html.UnmarshalOptions{}), "out has to be a pointer to a slice")
+		assert.EqualError(t, pdf2html.UnmarshalTable(nil, &[]string{}, pdf2html.UnmarshalOptions{}), "out has to be a pointer to a slice of structs")
+
+		type unknown struct {
+			Value string `pdftable:"col=0,format=money"`
+		}
+		assert.EqualError(t, pdf2html.UnmarshalTable(nil, &[]unknown{}, pdf2html.UnmarshalOptions{}), `unknown format "money" of field Value`)
+	})
+}
+
+func TestNormalizeNumber(t *testing.T) {
+	german := pdf2html.UnmarshalOptions{DecimalSeparator: ',', ThousandsSeparator: '.'}
+
+	for _, test := range []struct {
+		raw      string
+		options  pdf2html.UnmarshalOptions
+		expected string
+	}{
+		{"1,234.50", pdf2html.UnmarshalOptions{}, "1234.50"},
+		{"1.234,50-", german, "-1234.50"},
+		{"(12,00) EUR", german, "-12.00"},
+		{"€ 7", german, "7"},
+	} {
+		number, err := pdf2html.NormalizeNumber(test.raw, test.options)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, number)
+	}
+
+	for _, raw := range []string{"1.2.3", "n/a", "2024-01-02", "12-34", "12 EURO"} {
+		_, err := pdf2html.NormalizeNumber(raw, pdf2html.UnmarshalOptions{})
+		assert.EqualError(t, err, fmt.Sprintf("invalid number %q", raw))
+	}
+}
@@ -4,77 +4,109 @@ import (
 	"encoding/xml"
 	"fmt"
 	"sort"
+	"strings"
 )
 
 type PdfXmlData struct {
-	XMLName  xml.Name `xml:"pdf2xml"`
-	Producer *string  `xml:"producer,attr,omitempty"`
-	Version  *string  `xml:"version,attr,omitempty"`
+	XMLName  xml.Name `xml:"pdf2xml" json:"-"`
+	Producer *string  `xml:"producer,attr,omitempty" json:"producer,omitempty"`
+	Version  *string  `xml:"version,attr,omitempty" json:"version,omitempty"`
 
-	Pages    []PdfXmlPage    `xml:"page,omitempty"`
-	Outlines []PdfXmlOutline `xml:"outline,omitempty"`
+	Pages    []PdfXmlPage    `xml:"page,omitempty" json:"pages,omitempty"`
+	Outlines []PdfXmlOutline `xml:"outline,omitempty" json:"outlines,omitempty"`
 }
 
 type PdfXmlOutline struct {
-	XMLName xml.Name `xml:"outline"`
+	XMLName xml.Name `xml:"outline" json:"-"`
 
-	Items    []PdfXmlOutlineItem `xml:"item,omitempty"`
-	Outlines []PdfXmlOutline     `xml:"outline,omitempty"`
+	Items    []PdfXmlOutlineItem `xml:"item,omitempty" json:"items,omitempty"`
+	Outlines []PdfXmlOutline     `xml:"outline,omitempty" json:"outlines,omitempty"`
 }
 
 type PdfXmlOutlineItem struct {
-	Page    *int    `xml:"page,attr,omitempty"`
-	Content *string `xml:",chardata"`
+	Page    *int    `xml:"page,attr,omitempty" json:"page,omitempty"`
+	Content *string `xml:",chardata" json:"content,omitempty"`
 }
 
 type PdfXmlPage struct {
-	XMLName xml.Name `xml:"page"`
-
-	PageNumber *int    `xml:"number,attr,omitempty"`
-	Position   *string `xml:"position,attr,omitempty"`
-	Top        *int    `xml:"top,attr,omitempty"`
-	Left       *int    `xml:"left,attr,omitempty"`
-	Width      *int    `xml:"width,attr,omitempty"`
-	Height     *int    `xml:"height,attr,omitempty"`
-
-	FontSpecs []PdfXmlFontSpec `xml:"fontspec,omitempty"`
-	Texts     []PdfXmlText     `xml:"text,omitempty"`
+	XMLName xml.Name `xml:"page" json:"-"`
+
+	PageNumber *int    `xml:"number,attr,omitempty" json:"number,omitempty"`
+	Position   *string `xml:"position,attr,omitempty" json:"position,omitempty"`
+	Top        *int    `xml:"top,attr,omitempty" json:"top,omitempty"`
+	Left       *int    `xml:"left,attr,omitempty" json:"left,omitempty"`
+	Width      *int    `xml:"width,attr,omitempty" json:"width,omitempty"`
+	Height     *int    `xml:"height,attr,omitempty" json:"height,omitempty"`
+
+	FontSpecs []PdfXmlFontSpec `xml:"fontspec,omitempty" json:"fontSpecs,omitempty"`
+	Texts     []PdfXmlText     `xml:"text,omitempty" json:"texts,omitempty"`
+	Images    []PdfXmlImage    `xml:"image,omitempty" json:"images,omitempty"`
 }
 
 type PdfXmlFontSpec struct {
-	XMLName xml.Name `xml:"fontspec"`
+	XMLName xml.Name `xml:"fontspec" json:"-"`
 
-	ID     *int    `xml:"id,attr"`
-	Size   *int    `xml:"size,attr"`
-	Family *string `xml:"family,attr"`
-	Color  *string `xml:"color,attr"`
+	ID     *int    `xml:"id,attr" json:"id"`
+	Size   *int    `xml:"size,attr" json:"size"`
+	Family *string `xml:"family,attr" json:"family"`
+	Color  *string `xml:"color,attr" json:"color"`
 }
 
 type PdfXmlText struct {
-	XMLName xml.Name `xml:"text"`
+	XMLName xml.Name `xml:"text" json:"-"`
+
+	Top    *int `xml:"top,attr" json:"top"`
+	Left   *int `xml:"left,attr" json:"left"`
+	Width  *int `xml:"width,attr" json:"width"`
+	Height *int `xml:"height,attr" json:"height"`
+	Font   *int `xml:"font,attr" json:"font,omitempty"` // id of the PdfXmlFontSpec
+
+	Text     *string     `xml:",chardata" json:"text,omitempty"` // text outside of any inline element
+	BoldText *string     `xml:"b" json:"boldText,omitempty"`     // text of the last <b> element
+	Runs     []PdfXmlRun `xml:"-" json:"runs,omitempty"`         // full content in order with its inline formatting
+}
+
+// Part of a text with the same inline formatting of <b>, <i> and <a href>
+type PdfXmlRun struct {
+	Text   string  `json:"text"`
+	Bold   bool    `json:"bold,omitempty"`
+	Italic bool    `json:"italic,omitempty"`
+	Href   *string `json:"href,omitempty"`
+}
 
-	Top    *int `xml:"top,attr"`
-	Left   *int `xml:"left,attr"`
-	Width  *int `xml:"width,attr"`
-	Height *int `xml:"height,attr"`
+type PdfXmlImage struct {
+	XMLName xml.Name `xml:"image" json:"-"`
 
-	Text     *string `xml:",chardata"`
-	BoldText *string `xml:"b"`
+	Top    *int    `xml:"top,attr" json:"top"`
+	Left   *int    `xml:"left,attr" json:"left"`
+	Width  *int    `xml:"width,attr" json:"width"`
+	Height *int    `xml:"height,attr" json:"height"`
+	Src    *string `xml:"src,attr" json:"src"`
 }
 
 type PdfXmlTableOption struct {
-	From, To int // In what area should the table be located
+	From, To   int                // In what area should the table be located
+	Start, End *PdfXmlTableAnchor // anchors replacing From and To, if they are found on the page
 
 	Columns               int // How many columns should the table have
 	GetColumnFunc         func(text PdfXmlText) (int, error)
 	AllowedHeightVariance int // Define what variance is allowed to be in the same line
 
-	FilterFunc *func(entry PdfXmlTableEntry) bool // function to filter entries, if set and return true, entry will be added to the result
+	FilterFunc  *func(entry PdfXmlTableEntry) bool // function to filter entries, if set and return true, entry will be added to the result
+	MergePolicy *MergePolicy                       // merges continuation lines into the previous row, if set
+}
+
+// Defines when a line continues the previous row, e.g. a wrapped description. If
+// both conditions are set, both have to match
+type MergePolicy struct {
+	KeyColumns []int // a line continues the previous row if it has no content in these columns, e.g. the date column
+	MaxGap     int   // a line continues the previous row if its top is at most this distance below the previous MaxTop, e.g. the line height
 }
 
 type PdfXmlTableEntry struct {
 	MinLeft, MaxLeft int // Entry's minimum and maximum position left
 	MinTop, MaxTop   int // Entry's minimum and maximum position top
+	Page             int // number of the page the entry is located on
 
 	top int // Internal fields for validating the same line functionality
 
@@ -82,55 +114,56 @@ type PdfXmlTableEntry struct {
 }
 
 type PdfXmlTableEntryContent struct {
-	Text     *string // Normal text of the entry
-	BoldText *string // surrounded with <b> tags text
+	Text     *string      // Normal text of the entry
+	BoldText *string      // surrounded with <b> tags text
+	Runs     []PdfXmlRun  // full content in order with its inline formatting
+	Font     *int         // id of the PdfXmlFontSpec
+	Lines    []PdfXmlText // all texts of the cell in order, the fields above are taken from the first one
 }
 
 type GetColumnCalculationInRangesOption struct {
-	From, To int // lower and upper limit where the column should start
+	From, To  int             // lower and upper limit where the column should start
+	Alignment ColumnAlignment // edge of the text that has to be in the range (default left)
 }
 
 const maxInt int = int(^uint(0) >> 1)
 
 // Extracts the table content upon a give configuration for the table
 func (p PdfXmlPage) ExtractTableContent(option PdfXmlTableOption) []*PdfXmlTableEntry {
-	texts := p.getSortedTexts(option.From, option.To)
+	table, _ := p.ExtractTableContentWithArea(option)
+	return table
+}
+
+// Extracts the table content like ExtractTableContent and reports the area resolved from the anchors.
+// Font specifications of anchors are resolved with the fontspecs of this page only
+func (p PdfXmlPage) ExtractTableContentWithArea(option PdfXmlTableOption) ([]*PdfXmlTableEntry, PdfXmlTableArea) {
+	return p.extractTableContent(option, fontSpecsByID([]PdfXmlPage{p}))
+}
+
+func (p PdfXmlPage) extractTableContent(option PdfXmlTableOption, fonts map[int]PdfXmlFontSpec) ([]*PdfXmlTableEntry, PdfXmlTableArea) {
+	area := p.resolveArea(option, fonts)
+	texts := p.getSortedTexts(area.From, area.To)
+	page := area.Page
 
 	table := []*PdfXmlTableEntry{}
 	for _, text := range texts {
-		var entry *PdfXmlTableEntry
 		if len(table) == 0 || !table[len(table)-1].isSameLine(text, option.AllowedHeightVariance) {
-			entry = &PdfXmlTableEntry{
+			// Reset internal variables
+			if len(table) != 0 {
+				table[len(table)-1].top = 0
+			}
+
+			table = append(table, &PdfXmlTableEntry{
 				top: *text.Top,
 
 				MinLeft: maxInt,
 				MaxLeft: 0,
 				MinTop:  maxInt,
 				MaxTop:  0,
+				Page:    page,
 
 				Content: make([]*PdfXmlTableEntryContent, option.Columns),
-			}
-
-			// Reset internal variables
-			if len(table) != 0 {
-				table[len(table)-1].top = 0
-			}
-
-			// check if old entry should stay or be removed through the filter func
-			if option.FilterFunc != nil && len(table) != 0 {
-				var fn func(entry PdfXmlTableEntry) bool = *option.FilterFunc
-
-				// If last entry is NIL or it should be filtered (FilterFunc returns false) override the last entry, if not add the new
-				if table[len(table)-1] == nil || !fn(*table[len(table)-1]) {
-					table[len(table)-1] = entry
-				} else {
-					table = append(table, entry)
-				}
-			} else {
-				table = append(table, entry)
-			}
-		} else {
-			entry = table[len(table)-1]
+			})
 		}
 
 		column, err := option.GetColumnFunc(text)
@@ -138,43 +171,95 @@ func (p PdfXmlPage) ExtractTableContent(option PdfXmlTableOption) []*PdfXmlTable
 			continue
 		}
 
-		entry.Content[column] = &PdfXmlTableEntryContent{
+		table[len(table)-1].add(column, text)
+	}
+
+	if len(table) != 0 {
+		// Reset internal variables
+		table[len(table)-1].top = 0
+	}
+
+	// Continuation lines are merged first, so the filter func gets the complete rows
+	if option.MergePolicy != nil {
+		table = option.MergePolicy.merge(table)
+	}
+
+	if option.FilterFunc != nil {
+		var fn func(entry PdfXmlTableEntry) bool = *option.FilterFunc
+
+		filtered := []*PdfXmlTableEntry{}
+		for _, entry := range table {
+			if fn(*entry) {
+				filtered = append(filtered, entry)
+			}
+		}
+		table = filtered
+	}
+
+	return table, area
+}
+
+// Adds the text to the cell of the column. The first text of a cell fills
+// its fields, all texts are kept in the lines
+func (e *PdfXmlTableEntry) add(column int, text PdfXmlText) {
+	content := e.Content[column]
+	if content == nil {
+		content = &PdfXmlTableEntryContent{
 			Text:     text.Text,
 			BoldText: text.BoldText,
+			Runs:     text.Runs,
+			Font:     text.Font,
 		}
+		e.Content[column] = content
+	}
+	content.Lines = append(content.Lines, text)
 
-		// Check for min/max
-		if entry.MinLeft > *text.Left {
-			entry.MinLeft = *text.Left
-		}
-		if entry.MaxLeft < *text.Left {
-			entry.MaxLeft = *text.Left
-		}
-		if entry.MinTop > *text.Top {
-			entry.MinTop = *text.Top
+	// Check for min/max
+	e.MinLeft = min(e.MinLeft, *text.Left)
+	e.MaxLeft = max(e.MaxLeft, *text.Left)
+	e.MinTop = min(e.MinTop, *text.Top)
+	e.MaxTop = max(e.MaxTop, *text.Top)
+}
+
+// Merges the rows that continue the previous row into it
+func (m MergePolicy) merge(table []*PdfXmlTableEntry) []*PdfXmlTableEntry {
+	merged := []*PdfXmlTableEntry{}
+	for _, entry := range table {
+		if len(merged) == 0 || !m.isContinuation(*merged[len(merged)-1], *entry) {
+			merged = append(merged, entry)
+			continue
 		}
-		if entry.MaxTop < *text.Top {
-			entry.MaxTop = *text.Top
+
+		previous := merged[len(merged)-1]
+		for column, content := range entry.Content {
+			if content == nil {
+				continue
+			}
+			for _, line := range content.Lines {
+				previous.add(column, line)
+			}
 		}
 	}
 
-	if len(table) != 0 {
-		// Reset internal variables
-		table[len(table)-1].top = 0
+	return merged
+}
 
-		// final check after last run through
-		if option.FilterFunc != nil {
-			var fn func(entry PdfXmlTableEntry) bool = *option.FilterFunc
+func (m MergePolicy) isContinuation(previous, entry PdfXmlTableEntry) bool {
+	if len(m.KeyColumns) == 0 && m.MaxGap <= 0 {
+		return false
+	}
 
-			// If last entry is NIL or it should be filtered (FilterFunc returns false) override the last entry, if not add the new
-			if table[len(table)-1] == nil || !fn(*table[len(table)-1]) {
-				// remove the last element
-				table = table[:len(table)-1]
-			}
+	for _, column := range m.KeyColumns {
+		if column < len(entry.Content) && entry.Content[column] != nil && strings.TrimSpace(entry.Content[column].Content()) != "" {
+			return false
 		}
 	}
 
-	return table
+	if m.MaxGap > 0 && entry.MinTop-previous.MaxTop > m.MaxGap {
+		return false
+	}
+
+	return true
 }
 
 // Provides a function upon variances around starting points the column matching
@@ -190,11 +275,13 @@ func GetColumnCalculationWithVariance(columnAveragePosition []int, allowedVarian
 	return GetColumnCalculationInRanges(rangeOptions)
 }
 
-// Provides a function upon ranges around starting points the column matching
+// Provides a function upon ranges around starting points the column matching. The
+// alignment of a range defines if the left, right or center edge of a text is used
 func GetColumnCalculationInRanges(columnRanges []GetColumnCalculationInRangesOption) func(text PdfXmlText) (int, error) {
 	return func(text PdfXmlText) (int, error) {
 		for i, r := range columnRanges {
-			if *text.Left >= r.From && *text.Left <= r.To {
+			position := alignedPosition(text, r.Alignment)
+			if position >= r.From && position <= r.To {
 				return i, nil
 			}
 		}
@@ -203,10 +290,92 @@ func GetColumnCalculationInRanges(columnRanges []GetColumnCalculationInRangesOpt
 	}
 }
 
+// Provides a function that matches the column with the largest share of the text width inside
+// its range. The share has to be at least minRatio (0-1)
+func GetColumnCalculationWithOverlap(columnRanges []GetColumnCalculationInRangesOption, minRatio float64) func(text PdfXmlText) (int, error) {
+	return func(text PdfXmlText) (int, error) {
+		left, right := textEdges(text)
+
+		column, best := -1, 0.0
+		for i, r := range columnRanges {
+			ratio := 0.0
+			if right > left {
+				ratio = float64(min(right, r.To)-max(left, r.From)) / float64(right-left)
+			} else if left >= r.From && left <= r.To {
+				ratio = 1
+			}
+
+			if ratio > best && ratio >= minRatio {
+				column, best = i, ratio
+			}
+		}
+
+		if column == -1 {
+			return -1, fmt.Errorf("cannot find correct column")
+		}
+
+		return column, nil
+	}
+}
+
+// Provides a function that matches the column with the range nearest to the aligned
+// edge of the text, so no text is dropped
+func GetColumnCalculationNearest(columnRanges []GetColumnCalculationInRangesOption) func(text PdfXmlText) (int, error) {
+	return func(text PdfXmlText) (int, error) {
+		column, best := -1, maxInt
+		for i, r := range columnRanges {
+			position := alignedPosition(text, r.Alignment)
+
+			distance := 0
+			if position < r.From {
+				distance = r.From - position
+			} else if position > r.To {
+				distance = position - r.To
+			}
+
+			if distance < best {
+				column, best = i, distance
+			}
+		}
+
+		if column == -1 {
+			return -1, fmt.Errorf("cannot find correct column")
+		}
+
+		return column, nil
+	}
+}
+
+// Get the position of the edge of the text for the alignment
+func alignedPosition(text PdfXmlText, alignment ColumnAlignment) int {
+	left, right := textEdges(text)
+
+	switch alignment {
+	case AlignRight:
+		return right
+	case AlignCenter:
+		return (left + right) / 2
+	}
+
+	return left
+}
+
 func (e PdfXmlTableEntry) isSameLine(text PdfXmlText, variance int) bool {
 	return (*text.Top - e.top) <= variance
 }
 
+// Get all texts with a position, sorted from top to bottom and left to right
+func (p PdfXmlPage) getPositionedTexts() []PdfXmlText {
+	positioned := PdfXmlPage{}
+	for _, text := range p.Texts {
+		if text.Top != nil && text.Left != nil {
+			positioned.Texts = append(positioned.Texts, text)
+		}
+	}
+
+	return positioned.getSortedTexts(minInt, maxInt)
+}
+
 func (p PdfXmlPage) getSortedTexts(from, to int) []PdfXmlText {
 	texts := []PdfXmlText{}
 
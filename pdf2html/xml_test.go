@@ -110,14 +110,17 @@ var (
 				{
 					Text:     pointerHelperFn("1 row - 1 column"),
 					BoldText: pointerHelperFn("test-text-bold"),
+					Lines:    []pdf2html.PdfXmlText{xmlPage.Texts[2]},
 				},
 				{
 					Text:     pointerHelperFn("1 row - 2 column"),
 					BoldText: pointerHelperFn("test-text-bold"),
+					Lines:    []pdf2html.PdfXmlText{xmlPage.Texts[3]},
 				},
 				{
 					Text:     pointerHelperFn("1 row - 3 column"),
 					BoldText: pointerHelperFn("test-text-bold"),
+					Lines:    []pdf2html.PdfXmlText{xmlPage.Texts[4]},
 				},
 			},
 		},
@@ -130,14 +133,17 @@ var (
 				{
 					Text:     pointerHelperFn("2 row - 1 column"),
 					BoldText: pointerHelperFn("test-text-bold"),
+					Lines:    []pdf2html.PdfXmlText{xmlPage.Texts[5]},
 				},
 				{
 					Text:     pointerHelperFn("2 row - 2 column"),
 					BoldText: pointerHelperFn("test-text-bold"),
+					Lines:    []pdf2html.PdfXmlText{xmlPage.Texts[6]},
 				},
 				{
 					Text:     pointerHelperFn("2 row - 3 column"),
 					BoldText: pointerHelperFn("test-text-bold"),
+					Lines:    []pdf2html.PdfXmlText{xmlPage.Texts[7]},
 				},
 			},
 		},
@@ -168,10 +174,12 @@ func TestExtractTableContent(t *testing.T) {
 		entryContent[0] = &pdf2html.PdfXmlTableEntryContent{
 			Text:     pointerHelperFn("3 row - 1 column - filter func remove (if filter func is set)"),
 			BoldText: pointerHelperFn("test-text-bold"),
+			Lines:    []pdf2html.PdfXmlText{xmlPage.Texts[10]},
 		}
 		entryContent[1] = &pdf2html.PdfXmlTableEntryContent{
 			Text:     pointerHelperFn("3 row - 2 column - filter func remove (if filter func is set)"),
 			BoldText: pointerHelperFn("test-text-bold"),
+			Lines:    []pdf2html.PdfXmlText{xmlPage.Texts[11]},
 		}
 		enhancedExpectedXmlTable := expectedXmlTable
 		enhancedExpectedXmlTable = append(enhancedExpectedXmlTable, &pdf2html.PdfXmlTableEntry{
@@ -338,3 +346,216 @@ func TestGetColumnCalculationInRanges(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractTableContentMergePolicy(t *testing.T) {
+	t.Helper()
+
+	text := func(top, left int, content string) pdf2html.PdfXmlText {
+		return pdf2html.PdfXmlText{Top: pointerHelperFn(top), Left: pointerHelperFn(left), Text: pointerHelperFn(content)}
+	}
+
+	page := pdf2html.PdfXmlPage{
+		Texts: []pdf2html.PdfXmlText{
+			text(100, 100, "01.01."),
+			text(100, 200, "Rent payment for"),
+			text(100, 400, "-50.00"),
+			text(112, 200, "the apartment"),
+			text(124, 200, "in January"),
+			text(140, 100, "02.01."),
+			text(140, 200, "Salary"),
+			text(140, 400, "100.00"),
+			text(180, 200, "Note far below"),
+		},
+	}
+
+	option := func(policy *pdf2html.MergePolicy) pdf2html.PdfXmlTableOption {
+		return pdf2html.PdfXmlTableOption{
+			From:                  0,
+			To:                    500,
+			Columns:               3,
+			GetColumnFunc:         pdf2html.GetColumnCalculationWithVariance([]int{100, 200, 400}, 10),
+			AllowedHeightVariance: 5,
+			MergePolicy:           policy,
+		}
+	}
+
+	t.Run("Without merge policy every line is a row", func(t *testing.T) {
+		table := page.ExtractTableContent(option(nil))
+		assert.Equal(t, 5, len(table))
+	})
+
+	t.Run("Merge lines with an empty key column", func(t *testing.T) {
+		table := page.ExtractTableContent(option(&pdf2html.MergePolicy{KeyColumns: []int{0}}))
+
+		assert.Equal(t, 2, len(table))
+		assert.Equal(t, "Rent payment for the apartment in January", table[0].Content[1].Content())
+		assert.Equal(t, "Rent payment for", *table[0].Content[1].Text)
+		assert.Equal(t, 3, len(table[0].Content[1].Lines))
+		assert.Equal(t, 100, table[0].MinTop)
+		assert.Equal(t, 124, table[0].MaxTop)
+		assert.Equal(t, "Salary Note far below", table[1].Content[1].Content())
+	})
+
+	t.Run("Merge lines within a gap", func(t *testing.T) {
+		table := page.ExtractTableContent(option(&pdf2html.MergePolicy{MaxGap: 14}))
+
+		assert.Equal(t, 3, len(table))
+		assert.Equal(t, "Rent payment for the apartment in January", table[0].Content[1].Content())
+		assert.Equal(t, "Salary", table[1].Content[1].Content())
+		assert.Equal(t, "Note far below", table[2].Content[1].Content())
+	})
+
+	t.Run("Both conditions have to match", func(t *testing.T) {
+		table := page.ExtractTableContent(option(&pdf2html.MergePolicy{KeyColumns: []int{0}, MaxGap: 20}))
+
+		assert.Equal(t, 3, len(table))
+		assert.Equal(t, "Salary", table[1].Content[1].Content())
+	})
+
+	t.Run("Filter func gets the merged rows", func(t *testing.T) {
+		filterFunc := func(entry pdf2html.PdfXmlTableEntry) bool {
+			return entry.Content[2] != nil
+		}
+
+		tableOption := option(&pdf2html.MergePolicy{MaxGap: 14})
+		tableOption.FilterFunc = &filterFunc
+
+		entries := page.ExtractTableContent(tableOption)
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, "Rent payment for the apartment in January", entries[0].Content[1].Content())
+	})
+
+	t.Run("Texts in the same cell are not overwritten", func(t *testing.T) {
+		samePage := pdf2html.PdfXmlPage{
+			Texts: []pdf2html.PdfXmlText{
+				text(100, 198, "first"),
+				text(100, 202, "second"),
+			},
+		}
+
+		table := samePage.ExtractTableContent(option(nil))
+		assert.Equal(t, 1, len(table))
+		assert.Equal(t, "first", *table[0].Content[1].Text)
+		assert.Equal(t, "first second", table[0].Content[1].Content())
+	})
+}
+
+func TestGetColumnCalculationWithAlignment(t *testing.T) {
+	t.Helper()
+
+	text := func(top, left, width int) pdf2html.PdfXmlText {
+		return pdf2html.PdfXmlText{Top: pointerHelperFn(top), Left: pointerHelperFn(left), Width: pointerHelperFn(width)}
+	}
+	amount := func(left, width int) pdf2html.PdfXmlText {
+		return text(100, left, width)
+	}
+
+	ranges := []pdf2html.GetColumnCalculationInRangesOption{
+		{From: 95, To: 105},
+		{From: 295, To: 305, Alignment: pdf2html.AlignCenter},
+		{From: 495, To: 505, Alignment: pdf2html.AlignRight},
+	}
+
+	t.Run("Match on the aligned edges", func(t *testing.T) {
+		fn := pdf2html.GetColumnCalculationInRanges(ranges)
+
+		testData := []struct {
+			Text   pdf2html.PdfXmlText
+			Column int
+		}{
+			{Text: amount(100, 80), Column: 0},
+			{Text: amount(280, 40), Column: 1},
+			{Text: amount(250, 100), Column: 1},
+			{Text: amount(470, 30), Column: 2},
+			{Text: amount(440, 62), Column: 2},
+			{Text: pdf2html.PdfXmlText{Left: pointerHelperFn(500)}, Column: 2},
+		}
+
+		for i, test := range testData {
+			t.Run(fmt.Sprintf("Run test %d", i), func(t *testing.T) {
+				column, err := fn(test.Text)
+				assert.Nil(t, err)
+				assert.Equal(t, test.Column, column)
+			})
+		}
+
+		column, err := fn(amount(440, 50))
+		assert.Equal(t, "cannot find correct column", err.Error())
+		assert.Equal(t, -1, column)
+	})
+
+	t.Run("Overlap ratio", func(t *testing.T) {
+		fn := pdf2html.GetColumnCalculationWithOverlap([]pdf2html.GetColumnCalculationInRangesOption{
+			{From: 100, To: 200},
+			{From: 200, To: 400},
+		}, 0.5)
+
+		column, err := fn(amount(150, 100))
+		assert.Nil(t, err)
+		assert.Equal(t, 0, column)
+
+		column, err = fn(amount(180, 100))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, column)
+
+		column, err = fn(pdf2html.PdfXmlText{Left: pointerHelperFn(120)})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, column)
+
+		column, err = fn(amount(380, 100))
+		assert.Equal(t, "cannot find correct column", err.Error())
+		assert.Equal(t, -1, column)
+
+		column, err = fn(pdf2html.PdfXmlText{Left: pointerHelperFn(20)})
+		assert.Equal(t, "cannot find correct column", err.Error())
+		assert.Equal(t, -1, column)
+	})
+
+	t.Run("Nearest column", func(t *testing.T) {
+		fn := pdf2html.GetColumnCalculationNearest(ranges)
+
+		testData := []struct {
+			Text   pdf2html.PdfXmlText
+			Column int
+		}{
+			{Text: amount(0, 10), Column: 0},
+			{Text: amount(100, 10), Column: 0},
+			{Text: amount(260, 10), Column: 1},
+			{Text: amount(420, 60), Column: 2},
+			{Text: amount(900, 10), Column: 2},
+		}
+
+		for i, test := range testData {
+			t.Run(fmt.Sprintf("Run test %d", i), func(t *testing.T) {
+				column, err := fn(test.Text)
+				assert.Nil(t, err)
+				assert.Equal(t, test.Column, column)
+			})
+		}
+
+		column, err := pdf2html.GetColumnCalculationNearest(nil)(amount(100, 10))
+		assert.Equal(t, "cannot find correct column", err.Error())
+		assert.Equal(t, -1, column)
+	})
+
+	t.Run("Right aligned amounts are not dropped", func(t *testing.T) {
+		page := pdf2html.PdfXmlPage{
+			Texts: []pdf2html.PdfXmlText{
+				text(100, 100, 40), text(100, 462, 38),
+				text(120, 100, 40), text(120, 430, 70),
+			},
+		}
+
+		table := page.ExtractTableContent(pdf2html.PdfXmlTableOption{
+			From:                  0,
+			To:                    200,
+			Columns:               3,
+			GetColumnFunc:         pdf2html.GetColumnCalculationInRanges(ranges),
+			AllowedHeightVariance: 5,
+		})
+
+		assert.Equal(t, 2, len(table))
+		assert.NotNil(t, table[0].Content[2])
+		assert.NotNil(t, table[1].Content[2])
+	})
+}
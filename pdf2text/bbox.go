@@ -0,0 +1,79 @@
+package pdf2text
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Page of the pdftotext -bbox output
+type BboxPage struct {
+	Number int        `xml:"-" json:"number"`           // page number in the document
+	Width  float64    `xml:"width,attr" json:"width"`   // page width in points
+	Height float64    `xml:"height,attr" json:"height"` // page height in points
+	Words  []BboxWord `xml:"word" json:"words"`
+}
+
+// Word with its bounding box of the pdftotext -bbox output
+type BboxWord struct {
+	XMin float64 `xml:"xMin,attr" json:"xMin"`
+	YMin float64 `xml:"yMin,attr" json:"yMin"`
+	XMax float64 `xml:"xMax,attr" json:"xMax"`
+	YMax float64 `xml:"yMax,attr" json:"yMax"`
+	Text string  `xml:",chardata" json:"text"`
+}
+
+type bboxHtml struct {
+	Pages []BboxPage `xml:"body>doc>page"`
+}
+
+// Get the words with their bounding boxes for a given file with options
+func (c Client) GetBbox(filePath string, options Options) ([]BboxPage, error) {
+	options.Bbox = true
+	options.BboxLayout = false
+	options.HtmlMeta = false
+	options.Tsv = false
+
+	out, err := c.Get(filePath, options)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return []BboxPage{}, nil
+	}
+
+	firstPage := 1
+	if options.FirstPage != nil {
+		firstPage = *options.FirstPage
+	}
+
+	return ParseBbox(*out, firstPage)
+}
+
+// Parses the pdftotext -bbox output, the pages are numbered starting at firstPage
+func ParseBbox(content string, firstPage int) ([]BboxPage, error) {
+	// pdftotext writes XHTML, but the header is not always strict XML
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	var doc bboxHtml
+	err := decoder.Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	pages := doc.Pages
+	if pages == nil {
+		pages = []BboxPage{}
+	}
+
+	for i := range pages {
+		pages[i].Number = firstPage + i
+		if pages[i].Words == nil {
+			pages[i].Words = []BboxWord{}
+		}
+	}
+
+	return pages, nil
+}
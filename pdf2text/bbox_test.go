@@ -0,0 +1,121 @@
+package pdf2text_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/stretchr/testify/assert"
+)
+
+var bboxContent = `<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+<html xmlns="http://www.w3.org/1999/xhtml">
+<head>
+<title>Microsoft Word - Dokument1</title>
+<meta name="Producer" content="Microsoft&reg; Word 2016"/>
+<meta name="CreationDate" content=""/>
+</head>
+<body>
+<doc>
+  <page width="595.320000" height="841.920000">
+    <word xMin="70.944000" yMin="71.184000" xMax="94.352000" yMax="86.064000">Test</word>
+    <word xMin="96.728000" yMin="71.184000" xMax="119.888000" yMax="86.064000">PDF</word>
+  </page>
+  <page width="595.320000" height="841.920000">
+  </page>
+</doc>
+</body>
+</html>
+`
+
+var expectedBboxPages = []pdf2text.BboxPage{
+	{
+		Number: 3,
+		Width:  595.32,
+		Height: 841.92,
+		Words: []pdf2text.BboxWord{
+			{XMin: 70.944, YMin: 71.184, XMax: 94.352, YMax: 86.064, Text: "Test"},
+			{XMin: 96.728, YMin: 71.184, XMax: 119.888, YMax: 86.064, Text: "PDF"},
+		},
+	},
+	{
+		Number: 4,
+		Width:  595.32,
+		Height: 841.92,
+		Words:  []pdf2text.BboxWord{},
+	},
+}
+
+func TestGetBbox(t *testing.T) {
+	t.Helper()
+	setupTests()
+	client, _ := pdf2text.NewClient()
+
+	t.Run("Check for successful GetBbox", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return &bboxContent, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		pages, err := client.GetBbox("filename", pdf2text.Options{
+			FirstPage:     pointerHelperFn(3),
+			HtmlMeta:      true,
+			Tsv:           true,
+			UserPassword:  pointerHelperFn("test-user-password"),
+			OwnerPassword: nil,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedBboxPages, pages)
+		assert.Equal(t, []string{"pdftotext",
+			"-f", "3",
+			"-bbox",
+			"-upw", "test-user-password",
+			"filename",
+			"-",
+		}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Empty output", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return nil, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		pages, err := client.GetBbox("filename", pdf2text.Options{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []pdf2text.BboxPage{}, pages)
+	})
+
+	t.Run("Error on execute", func(t *testing.T) {
+		runMock.Reset()
+
+		pages, err := client.GetBbox("filename", pdf2text.Options{})
+
+		assert.Nil(t, pages)
+		assert.Equal(t, "GENERAL ERROR", err.Error())
+		assert.Equal(t, []string{"pdftotext", "-bbox", "filename", "-"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+}
+
+func TestParseBbox(t *testing.T) {
+	t.Run("Check first page numbering", func(t *testing.T) {
+		pages, err := pdf2text.ParseBbox(bboxContent, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(pages))
+		assert.Equal(t, 1, pages[0].Number)
+		assert.Equal(t, 2, pages[1].Number)
+	})
+
+	t.Run("Invalid content", func(t *testing.T) {
+		pages, err := pdf2text.ParseBbox("<html><body><doc><page width=\"abc\"></page></doc></body></html>", 1)
+
+		assert.Nil(t, pages)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,146 @@
+package pdf2text
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type BoilerplateOptions struct {
+	MinRatio float64 // share of the pages a line has to be found on (default 0.5)
+	Lines    int     // number of non-empty lines at the top and the bottom of a page that are checked (default 3)
+}
+
+// Line repeated at the same position on several pages, e.g. a running head, a footer or a page number
+type Boilerplate struct {
+	Pattern string `json:"pattern"` // lower case content with single spaces and numbers replaced by #
+	Line    int    `json:"line"`    // non-empty line of the page starting at 1, negative lines are counted from the bottom
+	Pages   []int  `json:"pages"`
+}
+
+const (
+	defaultBoilerplateRatio = 0.5
+	defaultBoilerplateLines = 3
+)
+
+var boilerplateNumberRegexp = regexp.MustCompile(`[0-9]+`)
+
+type boilerplateKey struct {
+	pattern string
+	line    int
+}
+
+// Get the lines repeated at the same position on at least MinRatio of the pages, at least two. The
+// pages are separated by form feeds and numbered starting at firstPage. Lines are compared without
+// case, spaces and numbers, so page numbers like "Page 3 of 10" are found. The result is ordered by
+// the lines from the top to the bottom
+func DetectBoilerplate(content string, firstPage int, options BoilerplateOptions) []Boilerplate {
+	if options.MinRatio == 0 {
+		options.MinRatio = defaultBoilerplateRatio
+	}
+	if options.Lines == 0 {
+		options.Lines = defaultBoilerplateLines
+	}
+
+	pages := boilerplatePages(content)
+	minPages := max(2, int(math.Ceil(options.MinRatio*float64(len(pages)))))
+
+	candidates := map[boilerplateKey][]int{}
+	for i, page := range pages {
+		for _, keys := range boilerplateKeys(strings.Split(page, "\n"), options.Lines) {
+			for _, key := range keys {
+				candidates[key] = append(candidates[key], firstPage+i)
+			}
+		}
+	}
+
+	result := []Boilerplate{}
+	for key, numbers := range candidates {
+		if len(numbers) >= minPages {
+			result = append(result, Boilerplate{Pattern: key.pattern, Line: key.line, Pages: numbers})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].Line, result[j].Line
+		if a != b {
+			// Lines from the top come first, 1, 2, ..., -2, -1
+			if (a > 0) != (b > 0) {
+				return a > 0
+			}
+			return a < b
+		}
+		return result[i].Pattern < result[j].Pattern
+	})
+
+	return result
+}
+
+// Get the content without the boilerplate lines and the removed boilerplate, see DetectBoilerplate.
+// The form feeds between the pages are kept
+func RemoveBoilerplate(content string, firstPage int, options BoilerplateOptions) (string, []Boilerplate) {
+	boilerplate := DetectBoilerplate(content, firstPage, options)
+	if options.Lines == 0 {
+		options.Lines = defaultBoilerplateLines
+	}
+
+	removed := map[boilerplateKey]bool{}
+	for _, entry := range boilerplate {
+		removed[boilerplateKey{pattern: entry.Pattern, line: entry.Line}] = true
+	}
+
+	pages := strings.Split(content, "\f")
+	for i, page := range pages {
+		lines := strings.Split(page, "\n")
+		keys := boilerplateKeys(lines, options.Lines)
+
+		result := []string{}
+		for l, line := range lines {
+			remove := false
+			for _, key := range keys[l] {
+				remove = remove || removed[key]
+			}
+			if !remove {
+				result = append(result, line)
+			}
+		}
+		pages[i] = strings.Join(result, "\n")
+	}
+
+	return strings.Join(pages, "\f"), boilerplate
+}
+
+// Get the pages of the content without the empty page after the last form feed
+func boilerplatePages(content string) []string {
+	pages := strings.Split(content, "\f")
+	if len(pages) > 1 && strings.TrimSpace(pages[len(pages)-1]) == "" {
+		pages = pages[:len(pages)-1]
+	}
+
+	return pages
+}
+
+// Get the keys of the first and last non-empty lines by the index of the line, a line can be
+// counted from the top and from the bottom on short pages
+func boilerplateKeys(lines []string, count int) map[int][]boilerplateKey {
+	indexes := []int{}
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			indexes = append(indexes, i)
+		}
+	}
+
+	keys := map[int][]boilerplateKey{}
+	for n, i := range indexes {
+		pattern := boilerplateNumberRegexp.ReplaceAllString(strings.ToLower(strings.Join(strings.Fields(lines[i]), " ")), "#")
+		if n < count {
+			keys[i] = append(keys[i], boilerplateKey{pattern: pattern, line: n + 1})
+		}
+		if n >= len(indexes)-count {
+			keys[i] = append(keys[i], boilerplateKey{pattern: pattern, line: n - len(indexes)})
+		}
+	}
+
+	return keys
+}
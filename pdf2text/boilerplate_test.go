@@ -0,0 +1,41 @@
+package pdf2text_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/stretchr/testify/assert"
+)
+
+const boilerplateContent = "ACME  Annual Report\n\nIntroduction\nFirst page\n\nPage 1 of 3\n\f" +
+	"ACME Annual Report\nSecond page\nConfidential\n   Page 2 of 3\n\f" +
+	"Third page\n\nPage 3 of 3\n\f"
+
+func TestDetectBoilerplate(t *testing.T) {
+	assert.Equal(t, []pdf2text.Boilerplate{
+		{Pattern: "acme annual report", Line: 1, Pages: []int{5, 6}},
+		{Pattern: "page # of #", Line: -1, Pages: []int{5, 6, 7}},
+	}, pdf2text.DetectBoilerplate(boilerplateContent, 5, pdf2text.BoilerplateOptions{}))
+
+	assert.Equal(t, []pdf2text.Boilerplate{
+		{Pattern: "page # of #", Line: -1, Pages: []int{1, 2, 3}},
+	}, pdf2text.DetectBoilerplate(boilerplateContent, 1, pdf2text.BoilerplateOptions{MinRatio: 1}))
+
+	// Short pages count their lines from the top and from the bottom
+	assert.Equal(t, []pdf2text.Boilerplate{
+		{Pattern: "page # of #", Line: 2, Pages: []int{1, 2}},
+		{Pattern: "page # of #", Line: -1, Pages: []int{1, 2}},
+	}, pdf2text.DetectBoilerplate("First\nPage 1 of 2\n\fSecond\nPage 2 of 2", 1, pdf2text.BoilerplateOptions{Lines: 2}))
+
+	assert.Empty(t, pdf2text.DetectBoilerplate("Page 1 of 1\n\f", 1, pdf2text.BoilerplateOptions{}))
+	assert.Empty(t, pdf2text.DetectBoilerplate("", 1, pdf2text.BoilerplateOptions{}))
+}
+
+func TestRemoveBoilerplate(t *testing.T) {
+	content, boilerplate := pdf2text.RemoveBoilerplate(boilerplateContent, 1, pdf2text.BoilerplateOptions{})
+
+	assert.Len(t, boilerplate, 2)
+	assert.Equal(t, "\nIntroduction\nFirst page\n\n\f"+
+		"Second page\nConfidential\n\f"+
+		"Third page\n\n\f", content)
+}
@@ -2,6 +2,7 @@ package pdf2text
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -86,7 +87,18 @@ func (c Client) checkVersion() error {
 // Execute function. Some outputs are using the stdin, some the stderr.
 // Therefore the three return values are representating stdout, stderr, error
 func (c Client) exec(args ...string) (*string, *string, error) {
-	cmd := tools.GetExecInstance().Command(client_cli, args...)
+	return c.execContext(context.Background(), args...)
+}
+
+// Execute function, the process is killed when the context is done
+func (c Client) execContext(ctx context.Context, args ...string) (*string, *string, error) {
+	command := tools.GetExecInstance().Command(client_cli, args...)
+
+	// The exec instance has no context, so the command is created again with the context
+	cmd := exec.CommandContext(ctx, command.Path, command.Args[1:]...)
+	cmd.Args = command.Args
+	cmd.Env = command.Env
+	cmd.Dir = command.Dir
 
 	var outBuffer bytes.Buffer
 	var errBuffer bytes.Buffer
@@ -97,6 +109,9 @@ func (c Client) exec(args ...string) (*string, *string, error) {
 
 	err := wrappedCmd.Run()
 
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -119,6 +134,11 @@ func (c Client) exec(args ...string) (*string, *string, error) {
 
 // Get the content for a given file with options
 func (c Client) Get(filePath string, options Options) (*string, error) {
+	return c.GetContext(context.Background(), filePath, options)
+}
+
+// Get the content for a given file with options, pdftotext is killed when the context is done
+func (c Client) GetContext(ctx context.Context, filePath string, options Options) (*string, error) {
 	args := []string{}
 	if options.FirstPage != nil {
 		args = append(args, "-f", strconv.Itoa(*options.FirstPage))
@@ -189,7 +209,7 @@ func (c Client) Get(filePath string, options Options) (*string, error) {
 
 	args = append(args, filePath, "-")
 
-	out, e, err := c.exec(args...)
+	out, e, err := c.execContext(ctx, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package pdf2text_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -463,3 +464,27 @@ func TestGet(t *testing.T) {
 		assert.Equal(t, []string{"pdftotext", "filename", "-"}, wrapperFnMock.GetLastInput().Cmd.Args)
 	})
 }
+
+func TestGetContext(t *testing.T) {
+	t.Helper()
+
+	t.Run("Check for cancelled context", func(t *testing.T) {
+		setupTests()
+		client, _ := pdf2text.NewClient()
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, nil
+		}
+		runMock.AddReturnValue(&fn)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		o, err := client.GetContext(ctx, "filename", pdf2text.Options{})
+
+		assert.Nil(t, o)
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, "pdftotext", wrapperFnMock.GetLastInput().Cmd.Args[0])
+	})
+}
@@ -0,0 +1,376 @@
+package pdf2x
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+	"github.com/nextunit-io/go-tools/tools"
+)
+
+// Client interface for pdftotext, implemented by pdf2text.Client
+type TextClient interface {
+	Get(filePath string, options pdf2text.Options) (*string, error)
+	GetBbox(filePath string, options pdf2text.Options) ([]pdf2text.BboxPage, error)
+}
+
+// Client interface for pdftohtml, implemented by pdf2html.Client
+type HTMLClient interface {
+	GetXML(filePath string, options pdf2html.Options) (*pdf2html.PdfXmlData, error)
+}
+
+// Client interface for pdfinfo, implemented by pdfinfo.Client
+type InfoClient interface {
+	Get(filePath string, options pdfinfo.Options) (*pdfinfo.Info, error)
+}
+
+type OpenOptions struct {
+	OwnerPassword *string // owner password (for encrypted files)
+	UserPassword  *string // user password (for encrypted files)
+	TempDir       *string // directory for the temporary copy of reader input (default os temp dir)
+
+	TextOptions pdf2text.Options // base options for pdftotext, pages and passwords are set by the document
+	HTMLOptions pdf2html.Options // base options for pdftohtml, passwords are set by the document
+
+	TextClient TextClient // pdftotext client, created on first use if not set
+	HTMLClient HTMLClient // pdftohtml client, created on first use if not set
+	InfoClient InfoClient // pdfinfo client, created on first use if not set
+}
+
+// PDF document that runs the poppler tools on demand and keeps their results
+type Document struct {
+	path    string
+	tempDir *string
+	options OpenOptions
+
+	mu    sync.Mutex
+	info  *pdfinfo.Info
+	xml   *pdf2html.PdfXmlData
+	texts map[int]*string
+	words map[int][]pdf2text.BboxWord
+}
+
+const tempFileName = "document.pdf"
+
+// Opens the document of the given file path
+func Open(path string, options OpenOptions) (*Document, error) {
+	if _, err := tools.GetOsInstance().Stat(path); err != nil {
+		return nil, err
+	}
+
+	return newDocument(path, nil, options), nil
+}
+
+// Opens the document of the given reader. The content is stored in a temporary
+// file, which is removed upon Close
+func OpenReader(reader io.Reader, options OpenOptions) (*Document, error) {
+	tempDir := tools.GetOsInstance().TempDir()
+	if options.TempDir != nil {
+		tempDir = *options.TempDir
+	}
+
+	dir, err := tools.GetOsInstance().MkdirTemp(tempDir, "pdf2x-*")
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, tempFileName)
+	err = writeFile(path, reader)
+	if err != nil {
+		tools.GetOsInstance().RemoveAll(dir)
+		return nil, err
+	}
+
+	return newDocument(path, &dir, options), nil
+}
+
+func newDocument(path string, tempDir *string, options OpenOptions) *Document {
+	return &Document{
+		path:    path,
+		tempDir: tempDir,
+		options: options,
+
+		texts: map[int]*string{},
+		words: map[int][]pdf2text.BboxWord{},
+	}
+}
+
+// Creation of files, which tools.OsInterface is missing. An os instance implementing it, e.g. a mock
+// of the tests, is used instead of the os package
+type FileCreator interface {
+	Create(name string) (io.WriteCloser, error)
+}
+
+func createFile(path string) (io.WriteCloser, error) {
+	if creator, ok := tools.GetOsInstance().(FileCreator); ok {
+		return creator.Create(path)
+	}
+
+	return os.Create(path)
+}
+
+func writeFile(path string, reader io.Reader) error {
+	file, err := createFile(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, reader)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Path of the PDF file the tools are running on
+func (d *Document) Path() string {
+	return d.path
+}
+
+// Removes the temporary files of the document
+func (d *Document) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.tempDir == nil {
+		return nil
+	}
+
+	err := tools.GetOsInstance().RemoveAll(*d.tempDir)
+	if err != nil {
+		return err
+	}
+
+	d.tempDir = nil
+	return nil
+}
+
+// Get the metadata of the document through pdfinfo
+func (d *Document) Metadata() (*pdfinfo.Info, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.metadata()
+}
+
+func (d *Document) metadata() (*pdfinfo.Info, error) {
+	if d.info != nil {
+		return d.info, nil
+	}
+
+	client, err := d.infoClient()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.Get(d.path, pdfinfo.Options{
+		OwnerPassword: d.options.OwnerPassword,
+		UserPassword:  d.options.UserPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.info = info
+	return info, nil
+}
+
+// Get the number of pages of the document
+func (d *Document) PageCount() (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.pageCount()
+}
+
+func (d *Document) pageCount() (int, error) {
+	info, err := d.metadata()
+	if err != nil {
+		return 0, err
+	}
+	if info.Pages == nil {
+		return 0, fmt.Errorf("cannot find the page count")
+	}
+
+	return *info.Pages, nil
+}
+
+func (d *Document) checkPage(page int) error {
+	count, err := d.pageCount()
+	if err != nil {
+		return err
+	}
+
+	if page < 1 || page > count {
+		return fmt.Errorf("page %d is out of range (1-%d)", page, count)
+	}
+
+	return nil
+}
+
+func (d *Document) textOptions(page int) pdf2text.Options {
+	options := d.options.TextOptions
+	options.FirstPage = &page
+	options.LastPage = &page
+	options.OwnerPassword = d.options.OwnerPassword
+	options.UserPassword = d.options.UserPassword
+
+	return options
+}
+
+// Get the text of a single page through pdftotext
+func (d *Document) Text(page int) (*string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if text, ok := d.texts[page]; ok {
+		return text, nil
+	}
+
+	err := d.checkPage(page)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := d.textClient()
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := client.Get(d.path, d.textOptions(page))
+	if err != nil {
+		return nil, err
+	}
+	if text == nil {
+		// Pages without text layer do not write any output
+		empty := ""
+		text = &empty
+	}
+
+	d.texts[page] = text
+	return text, nil
+}
+
+// Get the words with their bounding boxes of a single page through pdftotext
+func (d *Document) Words(page int) ([]pdf2text.BboxWord, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if words, ok := d.words[page]; ok {
+		return words, nil
+	}
+
+	err := d.checkPage(page)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := d.textClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := client.GetBbox(d.path, d.textOptions(page))
+	if err != nil {
+		return nil, err
+	}
+
+	words := []pdf2text.BboxWord{}
+	if len(pages) != 0 {
+		words = pages[0].Words
+	}
+
+	d.words[page] = words
+	return words, nil
+}
+
+// Get the XML data of the whole document through pdftohtml
+func (d *Document) XML() (*pdf2html.PdfXmlData, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.xmlData()
+}
+
+func (d *Document) xmlData() (*pdf2html.PdfXmlData, error) {
+	if d.xml != nil {
+		return d.xml, nil
+	}
+
+	client, err := d.htmlClient()
+	if err != nil {
+		return nil, err
+	}
+
+	options := d.options.HTMLOptions
+	options.OwnerPassword = d.options.OwnerPassword
+	options.UserPassword = d.options.UserPassword
+
+	data, err := client.GetXML(d.path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	d.xml = data
+	return data, nil
+}
+
+// Get the outline of the document, it is taken from the pdftohtml XML data
+func (d *Document) Outline() ([]pdf2html.PdfXmlOutline, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := d.xmlData()
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Outlines == nil {
+		return []pdf2html.PdfXmlOutline{}, nil
+	}
+
+	return data.Outlines, nil
+}
+
+func (d *Document) textClient() (TextClient, error) {
+	if d.options.TextClient == nil {
+		client, err := pdf2text.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		d.options.TextClient = client
+	}
+
+	return d.options.TextClient, nil
+}
+
+func (d *Document) htmlClient() (HTMLClient, error) {
+	if d.options.HTMLClient == nil {
+		client, err := pdf2html.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		d.options.HTMLClient = client
+	}
+
+	return d.options.HTMLClient, nil
+}
+
+func (d *Document) infoClient() (InfoClient, error) {
+	if d.options.InfoClient == nil {
+		client, err := pdfinfo.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		d.options.InfoClient = client
+	}
+
+	return d.options.InfoClient, nil
+}
@@ -0,0 +1,394 @@
+package pdf2x_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-pdf2X/pdf2x"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+	"github.com/nextunit-io/go-tools/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTextClient struct {
+	getCalls  []pdf2text.Options
+	bboxCalls []pdf2text.Options
+	err       error
+}
+
+func (c *testTextClient) Get(filePath string, options pdf2text.Options) (*string, error) {
+	c.getCalls = append(c.getCalls, options)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	text := fmt.Sprintf("%s page %d", filePath, *options.FirstPage)
+	return &text, nil
+}
+
+func (c *testTextClient) GetBbox(filePath string, options pdf2text.Options) ([]pdf2text.BboxPage, error) {
+	c.bboxCalls = append(c.bboxCalls, options)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return []pdf2text.BboxPage{
+		{
+			Number: *options.FirstPage,
+			Words: []pdf2text.BboxWord{
+				{XMin: 1, YMin: 2, XMax: 3, YMax: 4, Text: fmt.Sprintf("word-%d", *options.FirstPage)},
+			},
+		},
+	}, nil
+}
+
+type testHTMLClient struct {
+	calls []pdf2html.Options
+	data  *pdf2html.PdfXmlData
+}
+
+func (c *testHTMLClient) GetXML(filePath string, options pdf2html.Options) (*pdf2html.PdfXmlData, error) {
+	c.calls = append(c.calls, options)
+	if c.data == nil {
+		return nil, fmt.Errorf("XML error")
+	}
+
+	return c.data, nil
+}
+
+type testInfoClient struct {
+	calls []pdfinfo.Options
+	info  *pdfinfo.Info
+}
+
+func (c *testInfoClient) Get(filePath string, options pdfinfo.Options) (*pdfinfo.Info, error) {
+	c.calls = append(c.calls, options)
+	if c.info == nil {
+		return nil, fmt.Errorf("INFO error")
+	}
+
+	return c.info, nil
+}
+
+// Os instance that creates the files in memory, see pdf2x.FileCreator
+type testCreateOs struct {
+	tools.OsInterface
+	files map[string]*bytes.Buffer
+	err   error
+}
+
+type testFile struct {
+	*bytes.Buffer
+}
+
+func (testFile) Close() error {
+	return nil
+}
+
+func (o *testCreateOs) Create(name string) (io.WriteCloser, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+
+	o.files[name] = &bytes.Buffer{}
+	return testFile{o.files[name]}, nil
+}
+
+func setupCreateOs(t *testing.T, err error) *testCreateOs {
+	createOs := &testCreateOs{OsInterface: tools.GetOsInstance(), files: map[string]*bytes.Buffer{}, err: err}
+	tools.SetOsInstance(createOs)
+	t.Cleanup(func() {
+		tools.SetOsInstance(createOs.OsInterface)
+	})
+
+	return createOs
+}
+
+func pointerHelperFn[T any](x T) *T {
+	return &x
+}
+
+func setupDocument(t *testing.T) (*pdf2x.Document, *testTextClient, *testHTMLClient, *testInfoClient) {
+	textClient := &testTextClient{}
+	htmlClient := &testHTMLClient{
+		data: &pdf2html.PdfXmlData{
+			Producer: pointerHelperFn("poppler"),
+			Outlines: []pdf2html.PdfXmlOutline{
+				{
+					Items: []pdf2html.PdfXmlOutlineItem{
+						{Page: pointerHelperFn(1), Content: pointerHelperFn("Chapter 1")},
+					},
+				},
+			},
+		},
+	}
+	infoClient := &testInfoClient{
+		info: &pdfinfo.Info{
+			Pages: pointerHelperFn(2),
+			Title: pointerHelperFn("Test PDF"),
+		},
+	}
+
+	doc, err := pdf2x.Open("../test/Test_PDF.pdf", pdf2x.OpenOptions{
+		OwnerPassword: pointerHelperFn("test-owner-password"),
+		UserPassword:  pointerHelperFn("test-user-password"),
+		TextOptions:   pdf2text.Options{Layout: true},
+		HTMLOptions:   pdf2html.Options{NoMerge: true},
+		TextClient:    textClient,
+		HTMLClient:    htmlClient,
+		InfoClient:    infoClient,
+	})
+	assert.Nil(t, err)
+
+	return doc, textClient, htmlClient, infoClient
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("Open file path", func(t *testing.T) {
+		doc, err := pdf2x.Open("../test/Test_PDF.pdf", pdf2x.OpenOptions{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "../test/Test_PDF.pdf", doc.Path())
+		assert.Nil(t, doc.Close())
+	})
+
+	t.Run("Open missing file", func(t *testing.T) {
+		doc, err := pdf2x.Open("../test/missing.pdf", pdf2x.OpenOptions{})
+
+		assert.Nil(t, doc)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Open reader", func(t *testing.T) {
+		tempDir := t.TempDir()
+
+		doc, err := pdf2x.OpenReader(strings.NewReader("%PDF-1.5 test"), pdf2x.OpenOptions{
+			TempDir: &tempDir,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, tempDir, filepath.Dir(filepath.Dir(doc.Path())))
+
+		content, err := os.ReadFile(doc.Path())
+		assert.Nil(t, err)
+		assert.Equal(t, "%PDF-1.5 test", string(content))
+
+		assert.Nil(t, doc.Close())
+		_, err = os.Stat(filepath.Dir(doc.Path()))
+		assert.True(t, os.IsNotExist(err))
+
+		// A second close does not fail
+		assert.Nil(t, doc.Close())
+	})
+
+	t.Run("Open reader with invalid temp dir", func(t *testing.T) {
+		tempDir := filepath.Join(t.TempDir(), "missing")
+
+		doc, err := pdf2x.OpenReader(strings.NewReader("%PDF-1.5 test"), pdf2x.OpenOptions{
+			TempDir: &tempDir,
+		})
+		assert.Nil(t, doc)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Open reader with the os instance", func(t *testing.T) {
+		tempDir := t.TempDir()
+		createOs := setupCreateOs(t, nil)
+
+		doc, err := pdf2x.OpenReader(strings.NewReader("%PDF-1.5 test"), pdf2x.OpenOptions{
+			TempDir: &tempDir,
+		})
+		assert.Nil(t, err)
+		assert.Len(t, createOs.files, 1)
+		assert.Equal(t, "%PDF-1.5 test", createOs.files[doc.Path()].String())
+		assert.Nil(t, doc.Close())
+	})
+
+	t.Run("Error on create", func(t *testing.T) {
+		tempDir := t.TempDir()
+		setupCreateOs(t, fmt.Errorf("CREATE error"))
+
+		doc, err := pdf2x.OpenReader(strings.NewReader("%PDF-1.5 test"), pdf2x.OpenOptions{
+			TempDir: &tempDir,
+		})
+		assert.Nil(t, doc)
+		assert.Equal(t, "CREATE error", err.Error())
+
+		// The temporary directory is removed
+		entries, err := os.ReadDir(tempDir)
+		assert.Nil(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Error on read", func(t *testing.T) {
+		tempDir := t.TempDir()
+
+		doc, err := pdf2x.OpenReader(iotest.ErrReader(fmt.Errorf("READ error")), pdf2x.OpenOptions{
+			TempDir: &tempDir,
+		})
+		assert.Nil(t, doc)
+		assert.Equal(t, "READ error", err.Error())
+
+		entries, err := os.ReadDir(tempDir)
+		assert.Nil(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestDocumentMetadata(t *testing.T) {
+	doc, _, _, infoClient := setupDocument(t)
+
+	info, err := doc.Metadata()
+	assert.Nil(t, err)
+	assert.Equal(t, "Test PDF", *info.Title)
+
+	count, err := doc.PageCount()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+
+	// pdfinfo should only run once
+	assert.Equal(t, 1, len(infoClient.calls))
+	assert.Equal(t, "test-owner-password", *infoClient.calls[0].OwnerPassword)
+	assert.Equal(t, "test-user-password", *infoClient.calls[0].UserPassword)
+
+	t.Run("Missing page count", func(t *testing.T) {
+		doc, _, _, infoClient := setupDocument(t)
+		infoClient.info.Pages = nil
+
+		count, err := doc.PageCount()
+		assert.Equal(t, 0, count)
+		assert.Equal(t, "cannot find the page count", err.Error())
+	})
+
+	t.Run("Error is not memoised", func(t *testing.T) {
+		doc, _, _, infoClient := setupDocument(t)
+		info := infoClient.info
+		infoClient.info = nil
+
+		_, err := doc.PageCount()
+		assert.Equal(t, "INFO error", err.Error())
+
+		infoClient.info = info
+		count, err := doc.PageCount()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, 2, len(infoClient.calls))
+	})
+}
+
+func TestDocumentText(t *testing.T) {
+	doc, textClient, _, _ := setupDocument(t)
+
+	text, err := doc.Text(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "../test/Test_PDF.pdf page 2", *text)
+
+	text, err = doc.Text(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "../test/Test_PDF.pdf page 2", *text)
+
+	text, err = doc.Text(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "../test/Test_PDF.pdf page 1", *text)
+
+	assert.Equal(t, 2, len(textClient.getCalls))
+	assert.Equal(t, pdf2text.Options{
+		FirstPage:     pointerHelperFn(2),
+		LastPage:      pointerHelperFn(2),
+		Layout:        true,
+		OwnerPassword: pointerHelperFn("test-owner-password"),
+		UserPassword:  pointerHelperFn("test-user-password"),
+	}, textClient.getCalls[0])
+
+	t.Run("Page out of range", func(t *testing.T) {
+		text, err := doc.Text(3)
+		assert.Nil(t, text)
+		assert.Equal(t, "page 3 is out of range (1-2)", err.Error())
+
+		text, err = doc.Text(0)
+		assert.Nil(t, text)
+		assert.Equal(t, "page 0 is out of range (1-2)", err.Error())
+	})
+
+	t.Run("Error on text client", func(t *testing.T) {
+		doc, textClient, _, _ := setupDocument(t)
+		textClient.err = fmt.Errorf("TEXT error")
+
+		text, err := doc.Text(1)
+		assert.Nil(t, text)
+		assert.Equal(t, "TEXT error", err.Error())
+	})
+}
+
+func TestDocumentWords(t *testing.T) {
+	doc, textClient, _, _ := setupDocument(t)
+
+	words, err := doc.Words(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []pdf2text.BboxWord{{XMin: 1, YMin: 2, XMax: 3, YMax: 4, Text: "word-1"}}, words)
+
+	_, err = doc.Words(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(textClient.bboxCalls))
+	assert.Equal(t, "test-user-password", *textClient.bboxCalls[0].UserPassword)
+
+	t.Run("Page out of range", func(t *testing.T) {
+		words, err := doc.Words(5)
+		assert.Nil(t, words)
+		assert.Equal(t, "page 5 is out of range (1-2)", err.Error())
+	})
+
+	t.Run("Error on text client", func(t *testing.T) {
+		doc, textClient, _, _ := setupDocument(t)
+		textClient.err = fmt.Errorf("BBOX error")
+
+		words, err := doc.Words(1)
+		assert.Nil(t, words)
+		assert.Equal(t, "BBOX error", err.Error())
+	})
+}
+
+func TestDocumentXML(t *testing.T) {
+	doc, _, htmlClient, _ := setupDocument(t)
+
+	data, err := doc.XML()
+	assert.Nil(t, err)
+	assert.Equal(t, "poppler", *data.Producer)
+
+	outline, err := doc.Outline()
+	assert.Nil(t, err)
+	assert.Equal(t, "Chapter 1", *outline[0].Items[0].Content)
+
+	// pdftohtml should only run once for XML and outline
+	assert.Equal(t, 1, len(htmlClient.calls))
+	assert.Equal(t, pdf2html.Options{
+		NoMerge:       true,
+		OwnerPassword: pointerHelperFn("test-owner-password"),
+		UserPassword:  pointerHelperFn("test-user-password"),
+	}, htmlClient.calls[0])
+
+	t.Run("Empty outline", func(t *testing.T) {
+		doc, _, htmlClient, _ := setupDocument(t)
+		htmlClient.data.Outlines = nil
+
+		outline, err := doc.Outline()
+		assert.Nil(t, err)
+		assert.Equal(t, []pdf2html.PdfXmlOutline{}, outline)
+	})
+
+	t.Run("Error on html client", func(t *testing.T) {
+		doc, _, htmlClient, _ := setupDocument(t)
+		htmlClient.data = nil
+
+		outline, err := doc.Outline()
+		assert.Nil(t, outline)
+		assert.Equal(t, "XML error", err.Error())
+	})
+}
@@ -0,0 +1,292 @@
+package pdfinfo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/nextunit-io/go-tools/tools"
+)
+
+type Client struct {
+	execClient  tools.ExecInterface
+	wrapperFunc func(cmd *exec.Cmd, stdout, stderr io.Writer) CmdWrapper
+}
+
+type CmdWrapper interface {
+	Run() error
+}
+
+type Options struct {
+	FirstPage     *int    // first page to convert
+	LastPage      *int    // last page to convert
+	Box           bool    // print the page bounding boxes
+	IsoDates      bool    // print the dates in ISO-8601 format
+	RawDates      bool    // print the undecoded date strings directly from the PDF file
+	Enc           *string // output text encoding name
+	OwnerPassword *string // owner password (for encrypted files)
+	UserPassword  *string // user password (for encrypted files)
+}
+
+type Info struct {
+	Title        *string // title of the document
+	Subject      *string // subject of the document
+	Keywords     *string // keywords of the document
+	Author       *string // author of the document
+	Creator      *string // application that created the original document
+	Producer     *string // application that produced the PDF
+	CreationDate *string // creation date as printed by pdfinfo
+	ModDate      *string // modification date as printed by pdfinfo
+	Pages        *int    // number of pages
+	Encrypted    *bool   // whether the document is encrypted
+	PageSize     *string // page size of the first page, e.g. "595.32 x 841.92 pts (A4)"
+	FileSize     *int    // file size in bytes
+	PdfVersion   *string // PDF version of the file
+
+	Fields map[string]string // all key value pairs of the pdfinfo output
+}
+
+var wrapCmd func(cmd *exec.Cmd, stdout, stderr io.Writer) CmdWrapper = func(cmd *exec.Cmd, stdout, stderr io.Writer) CmdWrapper {
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd
+}
+
+const (
+	client_cli = "pdfinfo"
+
+	versionCheck = ">= 24.11.0, < 25.0"
+)
+
+// check if the version of pdfinfo is working with this library
+func (c Client) checkVersion() error {
+	v, err := c.GetVersion()
+	if err != nil {
+		return fmt.Errorf("cannot check version of %s", client_cli)
+	}
+
+	versionObj, err := version.NewVersion(*v)
+	if err != nil {
+		return err
+	}
+
+	versionConstraint, err := version.NewConstraint(versionCheck)
+	if err != nil {
+		return err
+	}
+
+	if !versionConstraint.Check(versionObj) {
+		return fmt.Errorf("version %s does not pass the version constraint %s", *v, versionCheck)
+	}
+
+	return nil
+}
+
+// Execute function. Some outputs are using the stdin, some the stderr.
+// Therefore the three return values are representating stdout, stderr, error
+func (c Client) exec(args ...string) (*string, *string, error) {
+	return c.execContext(context.Background(), args...)
+}
+
+// Execute function, the process is killed when the context is done
+func (c Client) execContext(ctx context.Context, args ...string) (*string, *string, error) {
+	command := tools.GetExecInstance().Command(client_cli, args...)
+
+	// The exec instance has no context, so the command is created again with the context
+	cmd := exec.CommandContext(ctx, command.Path, command.Args[1:]...)
+	cmd.Args = command.Args
+	cmd.Env = command.Env
+	cmd.Dir = command.Dir
+
+	var outBuffer bytes.Buffer
+	var errBuffer bytes.Buffer
+	cmd.Stdout = &outBuffer
+	cmd.Stderr = &errBuffer
+
+	wrappedCmd := c.wrapperFunc(cmd, &outBuffer, &errBuffer)
+
+	err := wrappedCmd.Run()
+
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	outputString := outBuffer.String()
+	errorString := errBuffer.String()
+
+	var outputStrPtr *string = nil
+	var errorStrPtr *string = nil
+
+	if outputString != "" {
+		outputStrPtr = &outputString
+	}
+	if errorString != "" {
+		errorStrPtr = &errorString
+	}
+
+	return outputStrPtr, errorStrPtr, nil
+}
+
+// Get the information for a given file with options
+func (c Client) Get(filePath string, options Options) (*Info, error) {
+	return c.GetContext(context.Background(), filePath, options)
+}
+
+// Get the information for a given file with options, pdfinfo is killed when the context is done
+func (c Client) GetContext(ctx context.Context, filePath string, options Options) (*Info, error) {
+	args := []string{}
+	if options.FirstPage != nil {
+		args = append(args, "-f", strconv.Itoa(*options.FirstPage))
+	}
+	if options.LastPage != nil {
+		args = append(args, "-l", strconv.Itoa(*options.LastPage))
+	}
+	if options.Box {
+		args = append(args, "-box")
+	}
+	if options.IsoDates {
+		args = append(args, "-isodates")
+	}
+	if options.RawDates {
+		args = append(args, "-rawdates")
+	}
+	if options.Enc != nil {
+		args = append(args, "-enc", *options.Enc)
+	}
+	if options.OwnerPassword != nil {
+		args = append(args, "-opw", *options.OwnerPassword)
+	}
+	if options.UserPassword != nil {
+		args = append(args, "-upw", *options.UserPassword)
+	}
+
+	args = append(args, filePath)
+
+	out, e, err := c.execContext(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	if e != nil {
+		return nil, fmt.Errorf("channel: %s", *e)
+	}
+	if out == nil {
+		return nil, fmt.Errorf("no valid output given")
+	}
+
+	return ParseInfo(*out)
+}
+
+// Parses the output of pdfinfo into the info structure
+func ParseInfo(content string) (*Info, error) {
+	info := Info{
+		Fields: map[string]string{},
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		info.Fields[key] = value
+
+		switch key {
+		case "Title":
+			info.Title = &value
+		case "Subject":
+			info.Subject = &value
+		case "Keywords":
+			info.Keywords = &value
+		case "Author":
+			info.Author = &value
+		case "Creator":
+			info.Creator = &value
+		case "Producer":
+			info.Producer = &value
+		case "CreationDate":
+			info.CreationDate = &value
+		case "ModDate":
+			info.ModDate = &value
+		case "Pages":
+			pages, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse pages: %s", value)
+			}
+			info.Pages = &pages
+		case "Encrypted":
+			encrypted := strings.HasPrefix(value, "yes")
+			info.Encrypted = &encrypted
+		case "Page size":
+			info.PageSize = &value
+		case "File size":
+			size, err := strconv.Atoi(strings.TrimSuffix(value, " bytes"))
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse file size: %s", value)
+			}
+			info.FileSize = &size
+		case "PDF version":
+			info.PdfVersion = &value
+		}
+	}
+
+	if len(info.Fields) == 0 {
+		return nil, fmt.Errorf("no valid output given")
+	}
+
+	return &info, nil
+}
+
+// Get the current pdfinfo version
+func (c Client) GetVersion() (*string, error) {
+	_, out, err := c.exec("-v")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if out == nil {
+		return nil, fmt.Errorf("cannot find the version")
+	}
+
+	r := regexp.MustCompile("pdfinfo version ([^\n]+)\n")
+	matches := r.FindStringSubmatch(*out)
+	if len(matches) != 2 {
+		return nil, fmt.Errorf("cannot find the version")
+	}
+
+	return &matches[1], nil
+}
+
+// Overwrite wrapper function for buffer
+func SetWrapperFunc(fn func(cmd *exec.Cmd, stdout, stderr io.Writer) CmdWrapper) {
+	wrapCmd = fn
+}
+
+// Get the pdfinfo client
+// Will return an error, if the installed CLI version is not valid
+func NewClient() (*Client, error) {
+	c := &Client{
+		execClient:  tools.GetExecInstance(),
+		wrapperFunc: wrapCmd,
+	}
+
+	// Check for valid CLI version before
+	// Do not do the check for getting the version
+	err := c.checkVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, err
+}
@@ -0,0 +1,449 @@
+package pdfinfo_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"testing"
+
+	gomock "github.com/nextunit-io/go-mock"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+	"github.com/nextunit-io/go-tools/tools"
+	"github.com/nextunit-io/go-tools/toolsmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	execMock      *toolsmock.ExecMock
+	wrapperFnMock *gomock.ToolMock[
+		struct {
+			Cmd    *exec.Cmd
+			Stdout io.Writer
+			Stderr io.Writer
+		},
+		pdfinfo.CmdWrapper,
+	]
+	runMock *gomock.ToolMock[
+		interface{},
+		func(cmd []string) (*string, *string, error),
+	]
+
+	versionWrapperMock *gomock.ToolMock[
+		interface{},
+		string,
+	]
+)
+
+type testVersionWrapper struct{}
+
+func (testVersionWrapper) Run() error {
+	runMock.AddInput(nil)
+
+	result, err := runMock.GetNextResult()
+	if err != nil {
+		return err
+	}
+	fn := *result
+
+	cmdInput := wrapperFnMock.GetLastInput()
+	outString, errString, err := fn(cmdInput.Cmd.Args)
+
+	if outString != nil {
+		cmdInput.Stdout.Write([]byte(*outString))
+	}
+	if errString != nil {
+		cmdInput.Stderr.Write([]byte(*errString))
+	}
+
+	return err
+}
+
+func pointerHelperFn[T any](x T) *T {
+	return &x
+}
+
+func setupTests() {
+	execMock = toolsmock.GetExecMock()
+	tools.SetExecInstance(execMock)
+
+	// Setup wrapper function mock
+	wrapperFnMock = gomock.GetMock[
+		struct {
+			Cmd    *exec.Cmd
+			Stdout io.Writer
+			Stderr io.Writer
+		},
+		pdfinfo.CmdWrapper,
+	](fmt.Errorf("WRAPPER general error"))
+
+	runMock = gomock.GetMock[interface{}, func(cmd []string) (*string, *string, error)](fmt.Errorf("GENERAL ERROR"))
+
+	pdfinfo.SetWrapperFunc(func(cmd *exec.Cmd, stdout, stderr io.Writer) pdfinfo.CmdWrapper {
+		wrapperFnMock.AddInput(struct {
+			Cmd    *exec.Cmd
+			Stdout io.Writer
+			Stderr io.Writer
+		}{
+			Cmd:    cmd,
+			Stdout: stdout,
+			Stderr: stderr,
+		})
+
+		result, err := wrapperFnMock.GetNextResult()
+		if err != nil {
+			panic(err.Error())
+		}
+
+		return *result
+	})
+
+	versionWrapperMock = gomock.GetMock[interface{}, string](fmt.Errorf("VERSIONWRAPPER general error"))
+
+	execMock.Mock.Command.SetAlwaysReturnFn(func() (**exec.Cmd, error) {
+		lastCommand := execMock.Mock.Command.GetLastInput()
+		cmd := exec.Command(lastCommand.Name, lastCommand.Arg...)
+		return &cmd, nil
+	})
+
+	wrapperFnMock.SetAlwaysReturnFn(func() (*pdfinfo.CmdWrapper, error) {
+		var wrapper pdfinfo.CmdWrapper = &testVersionWrapper{}
+		return &wrapper, nil
+	})
+
+	setupInitialVersion()
+}
+
+func setupInitialVersion() {
+	runMock.Reset()
+	fn := func(cmd []string) (*string, *string, error) {
+		versionReturnValue := `pdfinfo version 24.11.0
+Copyright 2005-2024 The Poppler Developers - http://poppler.freedesktop.org
+Copyright 1996-2011, 2022 Glyph & Cog, LLC`
+		return nil, &versionReturnValue, nil
+	}
+
+	runMock.AddReturnValue(&fn)
+}
+
+func TestGetClient(t *testing.T) {
+	t.Helper()
+	setupTests()
+
+	client, err := pdfinfo.NewClient()
+	assert.Nil(t, err)
+	assert.NotNil(t, client)
+	assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+
+	// Second try should fail, because there will be no version sent back upon the second time
+	client, err = pdfinfo.NewClient()
+	assert.Nil(t, client)
+	assert.Equal(t, "cannot check version of pdfinfo", err.Error())
+	assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+
+	// Too low version
+	fn := func(cmd []string) (*string, *string, error) {
+		versionReturnValue := `pdfinfo version 24.10.100
+Copyright 2005-2024 The Poppler Developers - http://poppler.freedesktop.org
+Copyright 1996-2011, 2022 Glyph & Cog, LLC`
+		return nil, &versionReturnValue, nil
+	}
+	runMock.AddReturnValue(&fn)
+
+	// Second try should fail, because there will be no version sent back upon the second time
+	client, err = pdfinfo.NewClient()
+	assert.Nil(t, client)
+	assert.Equal(t, "version 24.10.100 does not pass the version constraint >= 24.11.0, < 25.0", err.Error())
+	assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+
+	// Too high version
+	fn = func(cmd []string) (*string, *string, error) {
+		versionReturnValue := `pdfinfo version 25.0.0
+Copyright 2005-2024 The Poppler Developers - http://poppler.freedesktop.org
+Copyright 1996-2011, 2022 Glyph & Cog, LLC`
+		return nil, &versionReturnValue, nil
+	}
+	runMock.AddReturnValue(&fn)
+
+	// Second try should fail, because there will be no version sent back upon the second time
+	client, err = pdfinfo.NewClient()
+	assert.Nil(t, client)
+	assert.Equal(t, "version 25.0.0 does not pass the version constraint >= 24.11.0, < 25.0", err.Error())
+	assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+}
+
+func TestGetVersion(t *testing.T) {
+	t.Helper()
+	setupTests()
+	client, _ := pdfinfo.NewClient()
+
+	versions := []string{"24.11.0", "1.0", "2.5", "100.2.4", "50.0.4-meta"}
+
+	for _, version := range versions {
+		t.Run(fmt.Sprintf("Check for version %s", version), func(t *testing.T) {
+			runMock.Reset()
+
+			fn := func(cmd []string) (*string, *string, error) {
+				versionReturnValue := fmt.Sprintf(`pdfinfo version %s
+Copyright 2005-2024 The Poppler Developers - http://poppler.freedesktop.org
+Copyright 1996-2011, 2022 Glyph & Cog, LLC`, version)
+				return nil, &versionReturnValue, nil
+			}
+
+			runMock.AddReturnValue(&fn)
+			v, err := client.GetVersion()
+
+			assert.Nil(t, err)
+			assert.Equal(t, version, *v)
+			assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+		})
+	}
+
+	t.Run("Version errors", func(t *testing.T) {
+		runMock.Reset()
+
+		v, err := client.GetVersion()
+
+		assert.Nil(t, v)
+		assert.Equal(t, "GENERAL ERROR", err.Error())
+		assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Version parse error", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			versionReturnValue := "invalidValue"
+			return nil, &versionReturnValue, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		v, err := client.GetVersion()
+		assert.Nil(t, v)
+		assert.Equal(t, "cannot find the version", err.Error())
+		assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Wrong output channel", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			versionReturnValue := "invalidValue"
+			return &versionReturnValue, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		v, err := client.GetVersion()
+		assert.Nil(t, v)
+		assert.Equal(t, "cannot find the version", err.Error())
+		assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Error on execute", func(t *testing.T) {
+		runMock.Reset()
+
+		v, err := client.GetVersion()
+		assert.Nil(t, v)
+		assert.Equal(t, "GENERAL ERROR", err.Error())
+		assert.Equal(t, []string{"pdfinfo", "-v"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+}
+
+var infoContent = `Title:           Microsoft Word - Dokument1
+Author:          Test Author
+Creator:         Microsoft Word
+Producer:        Microsoft: Print To PDF
+CreationDate:    Thu Jan  5 10:00:00 2023 CET
+ModDate:         Thu Jan  5 10:00:00 2023 CET
+Custom Metadata: no
+Metadata Stream: yes
+Tagged:          no
+UserProperties:  no
+Suspects:        no
+Form:            none
+JavaScript:      no
+Pages:           2
+Encrypted:       no
+Page size:       595.32 x 841.92 pts (A4)
+Page rot:        0
+File size:       12345 bytes
+Optimized:       no
+PDF version:     1.5
+`
+
+func TestGet(t *testing.T) {
+	t.Helper()
+	setupTests()
+	client, _ := pdfinfo.NewClient()
+
+	t.Run("Check for successful get", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return &infoContent, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		info, err := client.Get("filename", pdfinfo.Options{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Microsoft Word - Dokument1", *info.Title)
+		assert.Equal(t, "Test Author", *info.Author)
+		assert.Equal(t, "Microsoft Word", *info.Creator)
+		assert.Equal(t, "Microsoft: Print To PDF", *info.Producer)
+		assert.Equal(t, "Thu Jan  5 10:00:00 2023 CET", *info.CreationDate)
+		assert.Equal(t, "Thu Jan  5 10:00:00 2023 CET", *info.ModDate)
+		assert.Equal(t, 2, *info.Pages)
+		assert.Equal(t, false, *info.Encrypted)
+		assert.Equal(t, "595.32 x 841.92 pts (A4)", *info.PageSize)
+		assert.Equal(t, 12345, *info.FileSize)
+		assert.Equal(t, "1.5", *info.PdfVersion)
+		assert.Nil(t, info.Subject)
+		assert.Nil(t, info.Keywords)
+		assert.Equal(t, "none", info.Fields["Form"])
+		assert.Equal(t, 20, len(info.Fields))
+		assert.Equal(t, []string{"pdfinfo", "filename"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Check for all flags", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return &infoContent, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		_, err := client.Get("filename", pdfinfo.Options{
+			FirstPage:     pointerHelperFn(20),
+			LastPage:      pointerHelperFn(40),
+			Box:           true,
+			IsoDates:      true,
+			RawDates:      true,
+			Enc:           pointerHelperFn("test-enc"),
+			OwnerPassword: pointerHelperFn("test-owner-password"),
+			UserPassword:  pointerHelperFn("test-user-password"),
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"pdfinfo",
+			"-f", "20",
+			"-l", "40",
+			"-box",
+			"-isodates",
+			"-rawdates",
+			"-enc", "test-enc",
+			"-opw", "test-owner-password",
+			"-upw", "test-user-password",
+			"filename",
+		}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Invalid page count", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			returnValue := "Pages: many\n"
+			return &returnValue, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		info, err := client.Get("filename", pdfinfo.Options{})
+
+		assert.Nil(t, info)
+		assert.Equal(t, "cannot parse pages: many", err.Error())
+	})
+
+	t.Run("Invalid file size", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			returnValue := "File size: big\n"
+			return &returnValue, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		info, err := client.Get("filename", pdfinfo.Options{})
+
+		assert.Nil(t, info)
+		assert.Equal(t, "cannot parse file size: big", err.Error())
+	})
+
+	t.Run("No key value pairs", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			returnValue := "invalidValue"
+			return &returnValue, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		info, err := client.Get("filename", pdfinfo.Options{})
+
+		assert.Nil(t, info)
+		assert.Equal(t, "no valid output given", err.Error())
+	})
+
+	t.Run("Empty output", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return nil, nil, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		info, err := client.Get("filename", pdfinfo.Options{})
+
+		assert.Nil(t, info)
+		assert.Equal(t, "no valid output given", err.Error())
+	})
+
+	t.Run("Wrong output channel", func(t *testing.T) {
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			returnValue := "invalidValue"
+			return nil, &returnValue, nil
+		}
+
+		runMock.AddReturnValue(&fn)
+		info, err := client.Get("filename", pdfinfo.Options{})
+
+		assert.Nil(t, info)
+		assert.Equal(t, "channel: invalidValue", err.Error())
+		assert.Equal(t, []string{"pdfinfo", "filename"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+
+	t.Run("Error on execute", func(t *testing.T) {
+		runMock.Reset()
+
+		info, err := client.Get("filename", pdfinfo.Options{})
+
+		assert.Nil(t, info)
+		assert.Equal(t, "GENERAL ERROR", err.Error())
+		assert.Equal(t, []string{"pdfinfo", "filename"}, wrapperFnMock.GetLastInput().Cmd.Args)
+	})
+}
+
+func TestGetContext(t *testing.T) {
+	t.Helper()
+
+	t.Run("Check for cancelled context", func(t *testing.T) {
+		setupTests()
+		client, _ := pdfinfo.NewClient()
+		runMock.Reset()
+
+		fn := func(cmd []string) (*string, *string, error) {
+			return pointerHelperFn("test-output"), nil, nil
+		}
+		runMock.AddReturnValue(&fn)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		o, err := client.GetContext(ctx, "filename", pdfinfo.Options{})
+
+		assert.Nil(t, o)
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, "pdfinfo", wrapperFnMock.GetLastInput().Cmd.Args[0])
+	})
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Decodes the options of a request. The JSON options are applied first, the query
+// parameters afterwards. Query parameters are matched case-insensitive against the
+// option field names, e.g. ?firstPage=2&layout=true. Setting one of the denied fields
+// returns an error
+func decodeOptions(query url.Values, jsonOptions string, target any, denied ...string) error {
+	if jsonOptions != "" {
+		err := json.Unmarshal([]byte(jsonOptions), target)
+		if err != nil {
+			return fmt.Errorf("invalid options: %w", err)
+		}
+	}
+
+	value := reflect.ValueOf(target).Elem()
+	fields := map[string]int{}
+	for i := 0; i < value.NumField(); i++ {
+		fields[strings.ToLower(value.Type().Field(i).Name)] = i
+	}
+
+	for key, values := range query {
+		index, ok := fields[strings.ToLower(key)]
+		if !ok || len(values) == 0 {
+			continue
+		}
+
+		err := setField(value.Field(index), values[len(values)-1])
+		if err != nil {
+			return fmt.Errorf("invalid option %s: %w", key, err)
+		}
+	}
+
+	for _, name := range denied {
+		field := value.FieldByName(name)
+		if field.IsValid() && !field.IsZero() {
+			return fmt.Errorf("option %s is not allowed", name)
+		}
+	}
+
+	return nil
+}
+
+func setField(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(v)
+	case reflect.Pointer:
+		elem := reflect.New(field.Type().Elem())
+		err := setField(elem.Elem(), raw)
+		if err != nil {
+			return err
+		}
+		field.Set(elem)
+	case reflect.Int:
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(v))
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(v)
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.Int {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+
+		values := reflect.MakeSlice(field.Type(), 0, 0)
+		for _, part := range strings.Split(raw, ",") {
+			v, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return err
+			}
+			values = reflect.Append(values, reflect.ValueOf(v))
+		}
+		field.Set(values)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
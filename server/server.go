@@ -0,0 +1,445 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"runtime"
+	"time"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-pdf2X/pdf2x"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+)
+
+// Client interface for pdftohtml, implemented by pdf2html.Client
+type HTMLClient interface {
+	GetXML(filePath string, options pdf2html.Options) (*pdf2html.PdfXmlData, error)
+	GetHTML(filePath string, options pdf2html.Options) (*string, error)
+}
+
+// Clients with these methods get the context of the request, so the CLI is killed when the request is done
+type textContextClient interface {
+	GetContext(ctx context.Context, filePath string, options pdf2text.Options) (*string, error)
+}
+
+type htmlContextClient interface {
+	GetXMLContext(ctx context.Context, filePath string, options pdf2html.Options) (*pdf2html.PdfXmlData, error)
+	GetHTMLContext(ctx context.Context, filePath string, options pdf2html.Options) (*string, error)
+}
+
+type infoContextClient interface {
+	GetContext(ctx context.Context, filePath string, options pdfinfo.Options) (*pdfinfo.Info, error)
+}
+
+type Config struct {
+	MaxBodySize    int64         // maximum size of a request body in bytes (default 32 MiB)
+	Timeout        time.Duration // maximum duration of a request (default 60 seconds)
+	MaxConcurrency int           // maximum number of conversions running at the same time (default number of CPUs)
+	TempDir        *string       // directory for the uploaded documents (default os temp dir)
+
+	TextClient pdf2x.TextClient // pdftotext client, created by New if not set
+	HTMLClient HTMLClient       // pdftohtml client, created by New if not set
+	InfoClient pdf2x.InfoClient // pdfinfo client, created by New if not set
+}
+
+// Options of the /tables endpoint, additionally to the pdftohtml options
+type TableOptions struct {
+	Page           int   // page number of the table (default 1)
+	From, To       int   // In what area should the table be located
+	Columns        []int // left positions of the columns
+	ColumnVariance int   // allowed variance around the column positions (default 10)
+	HeightVariance int   // allowed height variance for texts in the same line (default 5)
+}
+
+// HTTP server that exposes the conversions of the poppler clients
+type Server struct {
+	config Config
+	slots  chan struct{}
+}
+
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e httpError) Error() string {
+	return e.err.Error()
+}
+
+const (
+	defaultMaxBodySize int64 = 32 << 20
+	defaultTimeout           = 60 * time.Second
+
+	formFileField    = "file"
+	formOptionsField = "options"
+)
+
+// pdftohtml options that change where the output is written, the conversion cannot read it with them
+var deniedHTMLOptions = []string{"Quite", "Stdout"}
+
+// Get the server. Clients that are not set in the configuration are created,
+// which returns an error if the installed CLI versions are not valid
+func New(config Config) (*Server, error) {
+	if config.MaxBodySize <= 0 {
+		config.MaxBodySize = defaultMaxBodySize
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxConcurrency <= 0 {
+		config.MaxConcurrency = runtime.NumCPU()
+	}
+
+	if config.TextClient == nil {
+		client, err := pdf2text.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		config.TextClient = client
+	}
+	if config.HTMLClient == nil {
+		client, err := pdf2html.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		config.HTMLClient = client
+	}
+	if config.InfoClient == nil {
+		client, err := pdfinfo.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		config.InfoClient = client
+	}
+
+	return &Server{
+		config: config,
+		slots:  make(chan struct{}, config.MaxConcurrency),
+	}, nil
+}
+
+// Get the HTTP handler with all endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /text", s.handle(s.text))
+	mux.HandleFunc("POST /html", s.handle(s.html))
+	mux.HandleFunc("POST /xml", s.handle(s.xml))
+	mux.HandleFunc("POST /tables", s.handle(s.tables))
+	mux.HandleFunc("POST /info", s.handle(s.info))
+
+	return mux
+}
+
+// Response of a conversion, either a JSON value or a raw body with its content type
+type response struct {
+	contentType string
+	body        []byte
+	value       any
+}
+
+type conversion func(ctx context.Context, query url.Values, path, jsonOptions string) (*response, error)
+
+func (s *Server) handle(convert conversion) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), s.config.Timeout)
+		defer cancel()
+
+		// Wait for a free slot of the concurrency limiter
+		select {
+		case s.slots <- struct{}{}:
+		case <-ctx.Done():
+			writeError(w, httpError{http.StatusServiceUnavailable, fmt.Errorf("too many concurrent requests")})
+			return
+		}
+
+		query := r.URL.Query()
+		r.Body = http.MaxBytesReader(w, r.Body, s.config.MaxBodySize)
+		doc, jsonOptions, err := s.openDocument(r)
+		if err != nil {
+			<-s.slots
+			writeError(w, err)
+			return
+		}
+
+		type outcome struct {
+			response *response
+			err      error
+		}
+
+		done := make(chan outcome, 1)
+		go func() {
+			// The slot and the document are released after the conversion finished,
+			// even if the request already timed out
+			defer func() { <-s.slots }()
+			defer doc.Close()
+			defer func() {
+				if r := recover(); r != nil {
+					done <- outcome{nil, fmt.Errorf("conversion failed: %v", r)}
+				}
+			}()
+
+			res, err := convert(ctx, query, doc.Path(), jsonOptions)
+			done <- outcome{res, err}
+		}()
+
+		select {
+		case result := <-done:
+			if result.err != nil {
+				writeError(w, result.err)
+				return
+			}
+			writeResponse(w, result.response)
+		case <-ctx.Done():
+			writeError(w, httpError{http.StatusGatewayTimeout, fmt.Errorf("conversion timed out")})
+		}
+	}
+}
+
+// Stores the PDF of the request in a temporary file. The PDF is either the
+// raw body or the "file" part of a multipart form, which can contain the
+// options as JSON in the "options" part
+func (s *Server) openDocument(r *http.Request) (*pdf2x.Document, string, error) {
+	openOptions := pdf2x.OpenOptions{TempDir: s.config.TempDir}
+	jsonOptions := r.URL.Query().Get(formOptionsField)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		doc, err := pdf2x.OpenReader(r.Body, openOptions)
+		if err != nil {
+			return nil, "", bodyError(err)
+		}
+		return doc, jsonOptions, nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", httpError{http.StatusBadRequest, err}
+	}
+
+	var doc *pdf2x.Document
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if doc != nil {
+				doc.Close()
+			}
+			return nil, "", bodyError(err)
+		}
+
+		switch part.FormName() {
+		case formFileField:
+			if doc != nil {
+				doc.Close()
+				return nil, "", httpError{http.StatusBadRequest, fmt.Errorf("only one file is allowed")}
+			}
+
+			doc, err = pdf2x.OpenReader(part, openOptions)
+			if err != nil {
+				return nil, "", bodyError(err)
+			}
+		case formOptionsField:
+			content, err := io.ReadAll(part)
+			if err != nil {
+				if doc != nil {
+					doc.Close()
+				}
+				return nil, "", bodyError(err)
+			}
+			jsonOptions = string(content)
+		}
+	}
+
+	if doc == nil {
+		return nil, "", httpError{http.StatusBadRequest, fmt.Errorf("missing form field %s", formFileField)}
+	}
+
+	return doc, jsonOptions, nil
+}
+
+func bodyError(err error) error {
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return httpError{http.StatusRequestEntityTooLarge, fmt.Errorf("request body is larger than %d bytes", maxBytesError.Limit)}
+	}
+
+	return httpError{http.StatusBadRequest, err}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	var e httpError
+	if errors.As(err, &e) {
+		status = e.status
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func writeResponse(w http.ResponseWriter, res *response) {
+	if res.value == nil {
+		w.Header().Set("Content-Type", res.contentType)
+		w.WriteHeader(http.StatusOK)
+		w.Write(res.body)
+		return
+	}
+
+	content, err := json.Marshal(res.value)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+func optionsError(err error) error {
+	return httpError{http.StatusBadRequest, err}
+}
+
+func (s *Server) text(ctx context.Context, query url.Values, path, jsonOptions string) (*response, error) {
+	options := pdf2text.Options{}
+	err := decodeOptions(query, jsonOptions, &options)
+	if err != nil {
+		return nil, optionsError(err)
+	}
+
+	var out *string
+	if client, ok := s.config.TextClient.(textContextClient); ok {
+		out, err = client.GetContext(ctx, path, options)
+	} else {
+		out, err = s.config.TextClient.Get(path, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	text := ""
+	if out != nil {
+		text = *out
+	}
+
+	return &response{value: map[string]string{"text": text}}, nil
+}
+
+func (s *Server) html(ctx context.Context, query url.Values, path, jsonOptions string) (*response, error) {
+	options := pdf2html.Options{}
+	err := decodeOptions(query, jsonOptions, &options, deniedHTMLOptions...)
+	if err != nil {
+		return nil, optionsError(err)
+	}
+
+	var out *string
+	if client, ok := s.config.HTMLClient.(htmlContextClient); ok {
+		out, err = client.GetHTMLContext(ctx, path, options)
+	} else {
+		out, err = s.config.HTMLClient.GetHTML(path, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{contentType: "text/html; charset=utf-8", body: []byte(*out)}, nil
+}
+
+func (s *Server) xml(ctx context.Context, query url.Values, path, jsonOptions string) (*response, error) {
+	options := pdf2html.Options{}
+	err := decodeOptions(query, jsonOptions, &options, deniedHTMLOptions...)
+	if err != nil {
+		return nil, optionsError(err)
+	}
+
+	data, err := s.getXML(ctx, path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{value: data}, nil
+}
+
+func (s *Server) tables(ctx context.Context, query url.Values, path, jsonOptions string) (*response, error) {
+	options := pdf2html.Options{}
+	err := decodeOptions(query, jsonOptions, &options, deniedHTMLOptions...)
+	if err != nil {
+		return nil, optionsError(err)
+	}
+
+	tableOptions := TableOptions{
+		Page:           1,
+		To:             int(^uint(0) >> 1),
+		ColumnVariance: 10,
+		HeightVariance: 5,
+	}
+	err = decodeOptions(query, jsonOptions, &tableOptions)
+	if err != nil {
+		return nil, optionsError(err)
+	}
+	if len(tableOptions.Columns) == 0 {
+		return nil, optionsError(fmt.Errorf("missing option columns"))
+	}
+
+	data, err := s.getXML(ctx, path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, page := range data.Pages {
+		if page.PageNumber == nil || *page.PageNumber != tableOptions.Page {
+			continue
+		}
+
+		table := page.ExtractTableContent(pdf2html.PdfXmlTableOption{
+			From:                  tableOptions.From,
+			To:                    tableOptions.To,
+			Columns:               len(tableOptions.Columns),
+			GetColumnFunc:         pdf2html.GetColumnCalculationWithVariance(tableOptions.Columns, tableOptions.ColumnVariance),
+			AllowedHeightVariance: tableOptions.HeightVariance,
+		})
+
+		return &response{value: map[string]any{"entries": table}}, nil
+	}
+
+	return nil, optionsError(fmt.Errorf("cannot find page %d", tableOptions.Page))
+}
+
+func (s *Server) info(ctx context.Context, query url.Values, path, jsonOptions string) (*response, error) {
+	options := pdfinfo.Options{}
+	err := decodeOptions(query, jsonOptions, &options)
+	if err != nil {
+		return nil, optionsError(err)
+	}
+
+	var info *pdfinfo.Info
+	if client, ok := s.config.InfoClient.(infoContextClient); ok {
+		info, err = client.GetContext(ctx, path, options)
+	} else {
+		info, err = s.config.InfoClient.Get(path, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{value: info}, nil
+}
+
+func (s *Server) getXML(ctx context.Context, path string, options pdf2html.Options) (*pdf2html.PdfXmlData, error) {
+	if client, ok := s.config.HTMLClient.(htmlContextClient); ok {
+		return client.GetXMLContext(ctx, path, options)
+	}
+
+	return s.config.HTMLClient.GetXML(path, options)
+}
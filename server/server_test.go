@@ -0,0 +1,420 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdf2text"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+	"github.com/nextunit-io/go-pdf2X/server"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTextClient struct {
+	mu      sync.Mutex
+	calls   []pdf2text.Options
+	content []string
+	delay   time.Duration
+}
+
+func (c *testTextClient) Get(filePath string, options pdf2text.Options) (*string, error) {
+	time.Sleep(c.delay)
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, options)
+	c.content = append(c.content, string(content))
+
+	if string(content) == "broken" {
+		return nil, fmt.Errorf("cannot convert")
+	}
+	if string(content) == "panic" {
+		panic("unexpected content")
+	}
+
+	text := "Test PDF"
+	return &text, nil
+}
+
+func (c *testTextClient) GetBbox(filePath string, options pdf2text.Options) ([]pdf2text.BboxPage, error) {
+	return []pdf2text.BboxPage{}, nil
+}
+
+// Text client that gets the context of the request
+type testContextTextClient struct {
+	*testTextClient
+	cancelled chan error
+}
+
+func (c *testContextTextClient) GetContext(ctx context.Context, filePath string, options pdf2text.Options) (*string, error) {
+	select {
+	case <-time.After(c.delay):
+	case <-ctx.Done():
+		c.cancelled <- ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	return c.testTextClient.Get(filePath, options)
+}
+
+type testHTMLClient struct {
+	calls []pdf2html.Options
+}
+
+func (c *testHTMLClient) GetXML(filePath string, options pdf2html.Options) (*pdf2html.PdfXmlData, error) {
+	c.calls = append(c.calls, options)
+
+	return &pdf2html.PdfXmlData{
+		Producer: pointerHelperFn("poppler"),
+		Pages: []pdf2html.PdfXmlPage{
+			{
+				PageNumber: pointerHelperFn(1),
+				Texts: []pdf2html.PdfXmlText{
+					{Top: pointerHelperFn(100), Left: pointerHelperFn(100), Text: pointerHelperFn("A1")},
+					{Top: pointerHelperFn(100), Left: pointerHelperFn(200), Text: pointerHelperFn("B1")},
+				},
+			},
+		},
+	}, nil
+}
+
+func (c *testHTMLClient) GetHTML(filePath string, options pdf2html.Options) (*string, error) {
+	c.calls = append(c.calls, options)
+	return pointerHelperFn("<html></html>"), nil
+}
+
+type testInfoClient struct {
+	calls []pdfinfo.Options
+}
+
+func (c *testInfoClient) Get(filePath string, options pdfinfo.Options) (*pdfinfo.Info, error) {
+	c.calls = append(c.calls, options)
+	return &pdfinfo.Info{Pages: pointerHelperFn(3)}, nil
+}
+
+func pointerHelperFn[T any](x T) *T {
+	return &x
+}
+
+func setupServer(t *testing.T, config server.Config) (*httptest.Server, *testTextClient, *testHTMLClient, *testInfoClient) {
+	textClient := &testTextClient{}
+	htmlClient := &testHTMLClient{}
+	infoClient := &testInfoClient{}
+
+	tempDir := t.TempDir()
+	config.TempDir = &tempDir
+	config.TextClient = textClient
+	config.HTMLClient = htmlClient
+	config.InfoClient = infoClient
+
+	s, err := server.New(config)
+	assert.Nil(t, err)
+
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+
+	return ts, textClient, htmlClient, infoClient
+}
+
+func readResponse(t *testing.T, res *http.Response) (int, string) {
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	assert.Nil(t, err)
+
+	return res.StatusCode, string(body)
+}
+
+func multipartBody(t *testing.T, file, options *string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if options != nil {
+		assert.Nil(t, writer.WriteField("options", *options))
+	}
+	if file != nil {
+		part, err := writer.CreateFormFile("file", "test.pdf")
+		assert.Nil(t, err)
+		part.Write([]byte(*file))
+	}
+	assert.Nil(t, writer.Close())
+
+	return body, writer.FormDataContentType()
+}
+
+func TestText(t *testing.T) {
+	t.Run("Raw body with query options", func(t *testing.T) {
+		ts, textClient, _, _ := setupServer(t, server.Config{})
+
+		res, err := http.Post(ts.URL+"/text?firstPage=2&layout=true&userPassword=secret", "application/pdf", strings.NewReader("%PDF"))
+		assert.Nil(t, err)
+
+		status, body := readResponse(t, res)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"text":"Test PDF"}`, body)
+		assert.Equal(t, []string{"%PDF"}, textClient.content)
+		assert.Equal(t, pdf2text.Options{
+			FirstPage:    pointerHelperFn(2),
+			Layout:       true,
+			UserPassword: pointerHelperFn("secret"),
+		}, textClient.calls[0])
+	})
+
+	t.Run("Multipart with JSON options", func(t *testing.T) {
+		ts, textClient, _, _ := setupServer(t, server.Config{})
+
+		body, contentType := multipartBody(t, pointerHelperFn("%PDF multipart"), pointerHelperFn(`{"LastPage": 4, "ColSpacing": 0.5}`))
+		res, err := http.Post(ts.URL+"/text?raw=true", contentType, body)
+		assert.Nil(t, err)
+
+		status, _ := readResponse(t, res)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{"%PDF multipart"}, textClient.content)
+		assert.Equal(t, pdf2text.Options{
+			LastPage:   pointerHelperFn(4),
+			ColSpacing: pointerHelperFn(float32(0.5)),
+			Raw:        true,
+		}, textClient.calls[0])
+	})
+
+	t.Run("Multipart without file", func(t *testing.T) {
+		ts, _, _, _ := setupServer(t, server.Config{})
+
+		body, contentType := multipartBody(t, nil, pointerHelperFn(`{}`))
+		res, err := http.Post(ts.URL+"/text", contentType, body)
+		assert.Nil(t, err)
+
+		status, content := readResponse(t, res)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "{\"error\":\"missing form field file\"}\n", content)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		ts, _, _, _ := setupServer(t, server.Config{})
+
+		res, err := http.Post(ts.URL+"/text?firstPage=abc", "application/pdf", strings.NewReader("%PDF"))
+		assert.Nil(t, err)
+		status, content := readResponse(t, res)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, content, "invalid option firstPage")
+
+		res, err = http.Post(ts.URL+"/text?options=%7Binvalid", "application/pdf", strings.NewReader("%PDF"))
+		assert.Nil(t, err)
+		status, content = readResponse(t, res)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Contains(t, content, "invalid options")
+	})
+
+	t.Run("Conversion error", func(t *testing.T) {
+		ts, _, _, _ := setupServer(t, server.Config{})
+
+		res, err := http.Post(ts.URL+"/text", "application/pdf", strings.NewReader("broken"))
+		assert.Nil(t, err)
+
+		status, content := readResponse(t, res)
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.Equal(t, "{\"error\":\"cannot convert\"}\n", content)
+	})
+
+	t.Run("Body too large", func(t *testing.T) {
+		ts, _, _, _ := setupServer(t, server.Config{MaxBodySize: 10})
+
+		res, err := http.Post(ts.URL+"/text", "application/pdf", strings.NewReader("%PDF more than ten bytes"))
+		assert.Nil(t, err)
+
+		status, content := readResponse(t, res)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+		assert.Equal(t, "{\"error\":\"request body is larger than 10 bytes\"}\n", content)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		ts, textClient, _, _ := setupServer(t, server.Config{Timeout: 20 * time.Millisecond})
+		textClient.delay = 200 * time.Millisecond
+
+		res, err := http.Post(ts.URL+"/text", "application/pdf", strings.NewReader("%PDF"))
+		assert.Nil(t, err)
+
+		status, content := readResponse(t, res)
+		assert.Equal(t, http.StatusGatewayTimeout, status)
+		assert.Equal(t, "{\"error\":\"conversion timed out\"}\n", content)
+	})
+
+	t.Run("Timeout cancels the context of the conversion", func(t *testing.T) {
+		textClient := &testContextTextClient{
+			testTextClient: &testTextClient{delay: 10 * time.Second},
+			cancelled:      make(chan error, 1),
+		}
+		tempDir := t.TempDir()
+		s, err := server.New(server.Config{
+			Timeout:    20 * time.Millisecond,
+			TempDir:    &tempDir,
+			TextClient: textClient,
+			HTMLClient: &testHTMLClient{},
+			InfoClient: &testInfoClient{},
+		})
+		assert.Nil(t, err)
+
+		ts := httptest.NewServer(s.Handler())
+		t.Cleanup(ts.Close)
+
+		res, err := http.Post(ts.URL+"/text", "application/pdf", strings.NewReader("%PDF"))
+		assert.Nil(t, err)
+
+		status, _ := readResponse(t, res)
+		assert.Equal(t, http.StatusGatewayTimeout, status)
+
+		select {
+		case err := <-textClient.cancelled:
+			assert.Equal(t, context.DeadlineExceeded, err)
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "the conversion has not been cancelled")
+		}
+	})
+
+	t.Run("Panic in the conversion", func(t *testing.T) {
+		ts, _, _, _ := setupServer(t, server.Config{MaxConcurrency: 1})
+
+		res, err := http.Post(ts.URL+"/text", "application/pdf", strings.NewReader("panic"))
+		assert.Nil(t, err)
+
+		status, content := readResponse(t, res)
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.Equal(t, "{\"error\":\"conversion failed: unexpected content\"}\n", content)
+
+		// The slot is released again
+		res, err = http.Post(ts.URL+"/text", "application/pdf", strings.NewReader("%PDF"))
+		assert.Nil(t, err)
+
+		status, _ = readResponse(t, res)
+		assert.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("Concurrency limit", func(t *testing.T) {
+		ts, textClient, _, _ := setupServer(t, server.Config{MaxConcurrency: 1, Timeout: 100 * time.Millisecond})
+		textClient.delay = 300 * time.Millisecond
+
+		statuses := make(chan int, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				res, err := http.Post(ts.URL+"/text", "application/pdf", strings.NewReader("%PDF"))
+				assert.Nil(t, err)
+				status, _ := readResponse(t, res)
+				statuses <- status
+			}()
+		}
+
+		results := []int{<-statuses, <-statuses}
+		assert.Contains(t, results, http.StatusServiceUnavailable)
+		assert.Contains(t, results, http.StatusGatewayTimeout)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		ts, _, _, _ := setupServer(t, server.Config{})
+
+		res, err := http.Get(ts.URL + "/text")
+		assert.Nil(t, err)
+
+		status, _ := readResponse(t, res)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+}
+
+func TestHTMLAndXML(t *testing.T) {
+	ts, _, htmlClient, _ := setupServer(t, server.Config{})
+
+	res, err := http.Post(ts.URL+"/html?zoom=2&singleDoc=true", "application/pdf", strings.NewReader("%PDF"))
+	assert.Nil(t, err)
+
+	status, body := readResponse(t, res)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "<html></html>", body)
+	assert.Equal(t, "text/html; charset=utf-8", res.Header.Get("Content-Type"))
+	assert.Equal(t, pdf2html.Options{Zoom: pointerHelperFn(float32(2)), SingleDoc: true}, htmlClient.calls[0])
+
+	res, err = http.Post(ts.URL+"/xml", "application/pdf", strings.NewReader("%PDF"))
+	assert.Nil(t, err)
+
+	status, body = readResponse(t, res)
+	assert.Equal(t, http.StatusOK, status)
+
+	data := pdf2html.PdfXmlData{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &data))
+	assert.Equal(t, "poppler", *data.Producer)
+
+	res, err = http.Post(ts.URL+"/html?quite=true", "application/pdf", strings.NewReader("%PDF"))
+	assert.Nil(t, err)
+
+	status, body = readResponse(t, res)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "{\"error\":\"option Quite is not allowed\"}\n", body)
+
+	res, err = http.Post(ts.URL+"/xml?options=%7B%22Stdout%22%3Atrue%7D", "application/pdf", strings.NewReader("%PDF"))
+	assert.Nil(t, err)
+
+	status, body = readResponse(t, res)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "{\"error\":\"option Stdout is not allowed\"}\n", body)
+	assert.Equal(t, 2, len(htmlClient.calls))
+}
+
+func TestTables(t *testing.T) {
+	ts, _, _, _ := setupServer(t, server.Config{})
+
+	res, err := http.Post(ts.URL+"/tables?columns=100,200", "application/pdf", strings.NewReader("%PDF"))
+	assert.Nil(t, err)
+
+	status, body := readResponse(t, res)
+	assert.Equal(t, http.StatusOK, status)
+
+	table := struct {
+		Entries []pdf2html.PdfXmlTableEntry `json:"entries"`
+	}{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &table))
+	assert.Equal(t, 1, len(table.Entries))
+	assert.Equal(t, "A1", *table.Entries[0].Content[0].Text)
+	assert.Equal(t, "B1", *table.Entries[0].Content[1].Text)
+
+	res, err = http.Post(ts.URL+"/tables", "application/pdf", strings.NewReader("%PDF"))
+	assert.Nil(t, err)
+	status, body = readResponse(t, res)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "{\"error\":\"missing option columns\"}\n", body)
+
+	res, err = http.Post(ts.URL+"/tables?page=2&columns=100", "application/pdf", strings.NewReader("%PDF"))
+	assert.Nil(t, err)
+	status, body = readResponse(t, res)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "{\"error\":\"cannot find page 2\"}\n", body)
+}
+
+func TestInfo(t *testing.T) {
+	ts, _, _, infoClient := setupServer(t, server.Config{})
+
+	res, err := http.Post(ts.URL+"/info?isoDates=true", "application/pdf", strings.NewReader("%PDF"))
+	assert.Nil(t, err)
+
+	status, body := readResponse(t, res)
+	assert.Equal(t, http.StatusOK, status)
+
+	info := pdfinfo.Info{}
+	assert.Nil(t, json.Unmarshal([]byte(body), &info))
+	assert.Equal(t, 3, *info.Pages)
+	assert.Equal(t, pdfinfo.Options{IsoDates: true}, infoClient.calls[0])
+}
@@ -0,0 +1,376 @@
+package templates
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+)
+
+const maxInt int = int(^uint(0) >> 1)
+
+// Confidence of a value that is not the first candidate next to the label, e.g. the second text right of it
+const laterCandidateConfidence = 0.8
+
+// Confidence of a label that only contains the label text instead of being equal to it
+const partialLabelConfidence = 0.9
+
+// Possible value of a field
+type candidate struct {
+	text       string
+	rect       pdf2html.Rect
+	confidence float64
+}
+
+// Applies the template to the document. The result contains everything that is found, the
+// missing required fields and values that cannot be converted are returned as Errors
+func (t *Template) Apply(data pdf2html.PdfXmlData) (*Result, error) {
+	result := &Result{
+		Template: t.Name,
+		Fields:   map[string]FieldResult{},
+		Tables:   map[string][]map[string]any{},
+	}
+	errs := Errors{}
+
+	indexes := map[int]*pdf2html.SpatialIndex{}
+	for _, page := range data.Pages {
+		if page.PageNumber != nil {
+			indexes[*page.PageNumber] = pdf2html.NewSpatialIndex(page)
+		}
+	}
+
+	for _, field := range t.Fields {
+		value, err := field.extract(data, indexes, t.Number)
+		if err != nil {
+			errs = append(errs, Error{Name: field.Name, Row: -1, Err: err})
+		}
+		result.Fields[field.Name] = value
+	}
+
+	for _, table := range t.Tables {
+		rows, tableErrs := table.extract(data, t.Number)
+		errs = append(errs, tableErrs...)
+		result.Tables[table.Name] = rows
+	}
+
+	if len(errs) != 0 {
+		return result, errs
+	}
+
+	return result, nil
+}
+
+func (f FieldTemplate) extract(data pdf2html.PdfXmlData, indexes map[int]*pdf2html.SpatialIndex, number *NumberFormat) (FieldResult, error) {
+	var lastErr error
+	for _, match := range data.FindAnchor(f.Label.tableAnchor()) {
+		index, ok := indexes[match.Page]
+		if !ok || (f.Page != 0 && match.Page != f.Page) {
+			continue
+		}
+
+		labelConfidence := 1.0
+		if f.Label.Text != "" && strings.TrimSpace(match.Text.Content()) != f.Label.Text {
+			labelConfidence = partialLabelConfidence
+		}
+
+		for _, candidate := range f.candidates(match.Text, index) {
+			text, ok := f.Conversion.match(candidate.text)
+			if !ok {
+				continue
+			}
+
+			value, err := f.Conversion.convert(text, number)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+
+			return FieldResult{
+				Value:      value,
+				Text:       text,
+				Found:      true,
+				Confidence: labelConfidence * candidate.confidence,
+				Page:       match.Page,
+				Rect:       candidate.rect,
+			}, nil
+		}
+	}
+
+	if lastErr != nil {
+		return FieldResult{}, lastErr
+	}
+	if f.Required {
+		return FieldResult{}, fmt.Errorf("not found")
+	}
+
+	return FieldResult{}, nil
+}
+
+// Get the possible values of the field in the order they are tried
+func (f FieldTemplate) candidates(label pdf2html.PdfXmlText, index *pdf2html.SpatialIndex) []candidate {
+	rect := label.Rect()
+	maxDistance := f.MaxDistance
+	if maxDistance <= 0 {
+		maxDistance = maxInt
+	}
+
+	candidates := []candidate{}
+	switch f.Direction {
+	case "area":
+		area := pdf2html.Rect{
+			Left:   rect.Left + f.Area.Left,
+			Top:    rect.Top + f.Area.Top,
+			Right:  rect.Left + f.Area.Left + f.Area.Width,
+			Bottom: rect.Top + f.Area.Top + f.Area.Height,
+		}
+
+		parts := []string{}
+		bounds := pdf2html.Rect{Left: maxInt, Top: maxInt}
+		for _, text := range index.TextsIn(area) {
+			parts = append(parts, strings.TrimSpace(text.Content()))
+
+			textRect := text.Rect()
+			bounds.Left, bounds.Top = min(bounds.Left, textRect.Left), min(bounds.Top, textRect.Top)
+			bounds.Right, bounds.Bottom = max(bounds.Right, textRect.Right), max(bounds.Bottom, textRect.Bottom)
+		}
+		if len(parts) != 0 {
+			candidates = append(candidates, candidate{text: strings.Join(parts, " "), rect: bounds, confidence: 1})
+		}
+	case "below":
+		for _, text := range index.Below(label) {
+			if text.Rect().Top-rect.Bottom > maxDistance {
+				break
+			}
+			candidates = append(candidates, candidate{text: strings.TrimSpace(text.Content()), rect: text.Rect()})
+		}
+	default:
+		// The value can be in the label text, e.g. "Invoice total: 12.00"
+		if rest := f.Label.rest(label.Content()); rest != "" {
+			candidates = append(candidates, candidate{text: rest, rect: rect})
+		}
+
+		for _, text := range index.RightOf(label) {
+			if text.Rect().Left-rect.Right > maxDistance {
+				break
+			}
+			candidates = append(candidates, candidate{text: strings.TrimSpace(text.Content()), rect: text.Rect()})
+		}
+	}
+
+	for i := range candidates {
+		if candidates[i].confidence != 0 {
+			continue
+		}
+
+		candidates[i].confidence = 1
+		if i > 0 {
+			candidates[i].confidence = laterCandidateConfidence
+		}
+	}
+
+	return candidates
+}
+
+// Get the text after the label in the content of the label text without a separating colon
+func (a Anchor) rest(content string) string {
+	content = strings.TrimSpace(content)
+
+	end := -1
+	if a.pattern != nil {
+		if match := a.pattern.FindStringIndex(content); match != nil {
+			end = match[1]
+		}
+	} else if a.Text != "" {
+		if i := strings.Index(content, a.Text); i >= 0 {
+			end = i + len(a.Text)
+		}
+	}
+	if end < 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimLeft(content[end:], ": \t"))
+}
+
+// Get the value in the text, false if the text does not match the pattern
+func (c Conversion) match(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", false
+	}
+	if c.pattern == nil {
+		return text, true
+	}
+
+	match := c.pattern.FindStringSubmatch(text)
+	if match == nil {
+		return "", false
+	}
+	if len(match) > 1 {
+		return strings.TrimSpace(match[1]), true
+	}
+
+	return strings.TrimSpace(match[0]), true
+}
+
+// Converts the text to the type of the conversion
+func (c Conversion) convert(text string, number *NumberFormat) (any, error) {
+	if c.Number != nil {
+		number = c.Number
+	}
+
+	switch c.Type {
+	case "int":
+		normalized, err := pdf2html.NormalizeNumber(text, number.options())
+		if err != nil {
+			return nil, err
+		}
+		return strconv.ParseInt(normalized, 10, 64)
+	case "decimal":
+		normalized, err := pdf2html.NormalizeNumber(text, number.options())
+		if err != nil {
+			return nil, err
+		}
+		return strconv.ParseFloat(normalized, 64)
+	case "date":
+		return time.Parse(c.Layout, text)
+	}
+
+	return text, nil
+}
+
+func (n *NumberFormat) options() pdf2html.UnmarshalOptions {
+	options := pdf2html.UnmarshalOptions{}
+	if n == nil {
+		return options
+	}
+
+	if n.Decimal != "" {
+		options.DecimalSeparator = []rune(n.Decimal)[0]
+	}
+	if n.Thousands != "" {
+		options.ThousandsSeparator = []rune(n.Thousands)[0]
+	}
+
+	return options
+}
+
+func (t TableTemplate) extract(data pdf2html.PdfXmlData, number *NumberFormat) ([]map[string]any, Errors) {
+	ranges := []pdf2html.GetColumnCalculationInRangesOption{}
+	columns := map[string]int{}
+	for i, column := range t.Columns {
+		ranges = append(ranges, pdf2html.GetColumnCalculationInRangesOption{
+			From:      column.From,
+			To:        column.To,
+			Alignment: alignments[column.Alignment],
+		})
+		columns[column.Name] = i
+	}
+
+	option := pdf2html.MultiPageTableOption{
+		PdfXmlTableOption: pdf2html.PdfXmlTableOption{
+			From:                  t.From,
+			To:                    maxInt,
+			Columns:               len(t.Columns),
+			GetColumnFunc:         pdf2html.GetColumnCalculationInRanges(ranges),
+			AllowedHeightVariance: t.HeightVariance,
+		},
+		FirstPage: t.FirstPage,
+		LastPage:  t.LastPage,
+	}
+	if t.To != nil {
+		option.To = *t.To
+	}
+	if option.AllowedHeightVariance <= 0 {
+		option.AllowedHeightVariance = 5
+	}
+	if t.Start != nil {
+		anchor := t.Start.tableAnchor()
+		option.Start = &anchor
+	}
+	if t.End != nil {
+		anchor := t.End.tableAnchor()
+		option.End = &anchor
+	}
+	if t.Merge != nil {
+		policy := &pdf2html.MergePolicy{MaxGap: t.Merge.MaxGap}
+		for _, name := range t.Merge.KeyColumns {
+			policy.KeyColumns = append(policy.KeyColumns, columns[name])
+		}
+		option.MergePolicy = policy
+	}
+	if t.Filter != nil {
+		filter := t.Filter.filterFunc(columns)
+		option.FilterFunc = &filter
+	}
+
+	rows := []map[string]any{}
+	errs := Errors{}
+	for i, entry := range data.ExtractTable(option) {
+		row := map[string]any{}
+		rowErrs := Errors{}
+		for j, column := range t.Columns {
+			value, err := column.value(cellText(entry, j), number)
+			if err != nil {
+				rowErrs = append(rowErrs, Error{Name: t.Name, Row: i, Err: fmt.Errorf("column %s: %w", column.Name, err)})
+				continue
+			}
+			row[column.Name] = value
+		}
+
+		if len(rowErrs) != 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, errs
+}
+
+// Get the converted value of a cell, nil for empty cells
+func (c ColumnTemplate) value(text string, number *NumberFormat) (any, error) {
+	if text == "" {
+		if c.Required {
+			return nil, fmt.Errorf("missing value")
+		}
+		return nil, nil
+	}
+
+	value, ok := c.Conversion.match(text)
+	if !ok {
+		return nil, fmt.Errorf("%q does not match the pattern", text)
+	}
+
+	return c.Conversion.convert(value, number)
+}
+
+func (f RowFilter) filterFunc(columns map[string]int) func(entry pdf2html.PdfXmlTableEntry) bool {
+	return func(entry pdf2html.PdfXmlTableEntry) bool {
+		for _, name := range f.Required {
+			if cellText(&entry, columns[name]) == "" {
+				return false
+			}
+		}
+
+		if f.exclude != nil {
+			for i := range entry.Content {
+				if f.exclude.MatchString(cellText(&entry, i)) {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+}
+
+func cellText(entry *pdf2html.PdfXmlTableEntry, column int) string {
+	if entry == nil || column >= len(entry.Content) || entry.Content[column] == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(entry.Content[column].Content())
+}
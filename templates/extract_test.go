@@ -0,0 +1,141 @@
+package templates_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+func text(top, left, width int, content string) pdf2html.PdfXmlText {
+	return pdf2html.PdfXmlText{
+		Top:    pointerHelperFn(top),
+		Left:   pointerHelperFn(left),
+		Width:  pointerHelperFn(width),
+		Height: pointerHelperFn(12),
+		Text:   pointerHelperFn(content),
+	}
+}
+
+// Invoice with the items table continued on the second page
+func invoiceData() pdf2html.PdfXmlData {
+	return pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{
+		{
+			PageNumber: pointerHelperFn(1),
+			Texts: []pdf2html.PdfXmlText{
+				text(50, 50, 80, "Invoice no."), text(50, 300, 60, "AB-1234"), text(20, 500, 40, "Page 1"),
+				text(80, 50, 40, "Date"), text(98, 50, 70, "15.03.2024"),
+				text(130, 50, 50, "Bill to"), text(150, 50, 80, "ACME Ltd"), text(165, 50, 60, "1 Road"),
+				text(200, 50, 80, "Description"), text(200, 300, 30, "Qty"), text(200, 450, 50, "Amount"),
+				text(220, 50, 60, "Widget"), text(220, 300, 10, "2"), text(220, 440, 50, "1.234,50"),
+				text(236, 50, 70, "with extra"),
+				text(252, 50, 60, "Gadget"), text(252, 300, 10, "1"), text(252, 460, 30, "10,00"),
+				text(270, 50, 100, "Carried forward"),
+			},
+		},
+		{
+			PageNumber: pointerHelperFn(2),
+			Texts: []pdf2html.PdfXmlText{
+				text(50, 50, 80, "Description"), text(50, 300, 30, "Qty"), text(50, 450, 50, "Amount"),
+				text(70, 50, 60, "Service"), text(70, 300, 10, "x"), text(70, 460, 30, "5,00"),
+				text(86, 50, 60, "Support"), text(86, 300, 10, "3"), text(86, 460, 30, "7,50"),
+				text(120, 50, 200, "Invoice total: 1.251,00"),
+			},
+		},
+	}}
+}
+
+func TestApply(t *testing.T) {
+	template, err := templates.Parse([]byte(invoiceTemplate))
+	assert.Nil(t, err)
+
+	result, err := template.Apply(invoiceData())
+	assert.Equal(t, "acme-invoice", result.Template)
+
+	t.Run("fields", func(t *testing.T) {
+		assert.Equal(t, templates.FieldResult{
+			Value:      "AB-1234",
+			Text:       "AB-1234",
+			Found:      true,
+			Confidence: 1,
+			Page:       1,
+			Rect:       pdf2html.Rect{Left: 300, Top: 50, Right: 360, Bottom: 62},
+		}, result.Fields["invoiceNumber"])
+
+		assert.Equal(t, templates.FieldResult{
+			Value:      1251.0,
+			Text:       "1.251,00",
+			Found:      true,
+			Confidence: 1,
+			Page:       2,
+			Rect:       pdf2html.Rect{Left: 50, Top: 120, Right: 250, Bottom: 132},
+		}, result.Fields["total"])
+
+		assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), result.Fields["date"].Value)
+		assert.Equal(t, 1.0, result.Fields["date"].Confidence)
+
+		assert.Equal(t, "ACME Ltd 1 Road", result.Fields["address"].Value)
+		assert.Equal(t, pdf2html.Rect{Left: 50, Top: 150, Right: 130, Bottom: 177}, result.Fields["address"].Rect)
+
+		assert.Equal(t, templates.FieldResult{}, result.Fields["reference"])
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		assert.Equal(t, []map[string]any{
+			{"description": "Widget with extra", "quantity": int64(2), "amount": 1234.5},
+			{"description": "Gadget", "quantity": int64(1), "amount": 10.0},
+			{"description": "Support", "quantity": int64(3), "amount": 7.5},
+		}, result.Tables["items"])
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var errs templates.Errors
+		assert.True(t, errors.As(err, &errs))
+		assert.Len(t, errs, 1)
+		assert.Equal(t, "items", errs[0].Name)
+		assert.Equal(t, 2, errs[0].Row)
+		assert.EqualError(t, err, `table items, row 2: column quantity: invalid number "x"`)
+	})
+}
+
+func TestApplyFields(t *testing.T) {
+	data := pdf2html.PdfXmlData{Pages: []pdf2html.PdfXmlPage{{
+		PageNumber: pointerHelperFn(1),
+		Texts: []pdf2html.PdfXmlText{
+			text(50, 50, 80, "Customer number:"), text(50, 200, 40, "n/a"), text(50, 300, 40, "4711"),
+			text(80, 50, 80, "Total"), text(80, 400, 40, "12.00"),
+			text(110, 50, 80, "Due"), text(110, 200, 40, "soon"),
+		},
+	}}}
+
+	template, err := templates.Parse([]byte(`
+fields:
+  - name: customer
+    label: {text: "Customer"}
+    type: int
+  - name: total
+    label: {text: "Total"}
+    maxDistance: 100
+    required: true
+  - name: due
+    label: {text: "Due"}
+    type: date
+    layout: "02.01.2006"
+  - name: page
+    label: {text: "Customer"}
+    page: 2
+`))
+	assert.Nil(t, err)
+
+	result, err := template.Apply(data)
+
+	// The first text right of the label is not a number, the second one is used with a lower confidence
+	assert.Equal(t, int64(4711), result.Fields["customer"].Value)
+	assert.InDelta(t, 0.72, result.Fields["customer"].Confidence, 0.0001)
+	assert.False(t, result.Fields["page"].Found)
+
+	assert.EqualError(t, err, "field total: not found\nfield due: parsing time \"soon\" as \"02.01.2006\": cannot parse \"soon\" as \"02\"")
+}
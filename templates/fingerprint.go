@@ -0,0 +1,217 @@
+package templates
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+)
+
+// Layout of a document: what produced it, the page size, the fonts and the positions of the
+// texts without numbers on the first page, which are usually the labels of the layout
+type Fingerprint struct {
+	Producer string   `yaml:"producer" json:"producer"` // producer of the PDF of pdfinfo, empty if unknown
+	Width    int      `yaml:"width" json:"width"`       // width of the first page
+	Height   int      `yaml:"height" json:"height"`     // height of the first page
+	Fonts    []string `yaml:"fonts" json:"fonts"`       // family/size/color of the fonts, sorted
+	Tokens   []Token  `yaml:"tokens" json:"tokens"`
+}
+
+// Text of the layout with its position
+type Token struct {
+	Text string `yaml:"text" json:"text"` // lower case text with single spaces
+	Left int    `yaml:"left" json:"left"`
+	Top  int    `yaml:"top" json:"top"`
+}
+
+const (
+	maxFingerprintTokens = 100
+	tokenTolerance       = 10 // maximum distance of the positions of the same token in two documents
+	pageSizeTolerance    = 2
+)
+
+// Weights of the parts of a fingerprint in the similarity
+const (
+	producerWeight = 0.1
+	pageSizeWeight = 0.2
+	fontsWeight    = 0.3
+	tokensWeight   = 0.4
+)
+
+// Prefix of embedded font subsets, e.g. IMPLXZ+Calibri, that differs between documents
+var fontSubsetRegexp = regexp.MustCompile(`^[A-Z]{6}\+`)
+
+// Computes the fingerprint of the layout of the document. The producer is taken from the pdfinfo
+// information, which can be nil
+func NewFingerprint(data pdf2html.PdfXmlData, info *pdfinfo.Info) Fingerprint {
+	fingerprint := Fingerprint{Fonts: []string{}, Tokens: []Token{}}
+	if info != nil && info.Producer != nil {
+		fingerprint.Producer = strings.TrimSpace(*info.Producer)
+	}
+
+	fonts := map[string]bool{}
+	for _, page := range data.Pages {
+		for _, fontSpec := range page.FontSpecs {
+			fonts[fontKey(fontSpec)] = true
+		}
+	}
+	for font := range fonts {
+		fingerprint.Fonts = append(fingerprint.Fonts, font)
+	}
+	sort.Strings(fingerprint.Fonts)
+
+	if len(data.Pages) == 0 {
+		return fingerprint
+	}
+
+	page := data.Pages[0]
+	if page.Width != nil {
+		fingerprint.Width = *page.Width
+	}
+	if page.Height != nil {
+		fingerprint.Height = *page.Height
+	}
+
+	for _, text := range page.Texts {
+		if text.Top == nil || text.Left == nil {
+			continue
+		}
+
+		content, ok := tokenText(text.Content())
+		if !ok {
+			continue
+		}
+		fingerprint.Tokens = append(fingerprint.Tokens, Token{Text: content, Left: *text.Left, Top: *text.Top})
+	}
+
+	sort.SliceStable(fingerprint.Tokens, func(i, j int) bool {
+		if fingerprint.Tokens[i].Top == fingerprint.Tokens[j].Top {
+			return fingerprint.Tokens[i].Left < fingerprint.Tokens[j].Left
+		}
+		return fingerprint.Tokens[i].Top < fingerprint.Tokens[j].Top
+	})
+	if len(fingerprint.Tokens) > maxFingerprintTokens {
+		fingerprint.Tokens = fingerprint.Tokens[:maxFingerprintTokens]
+	}
+
+	return fingerprint
+}
+
+func fontKey(fontSpec pdf2html.PdfXmlFontSpec) string {
+	family, size, color := "", "", ""
+	if fontSpec.Family != nil {
+		family = fontSubsetRegexp.ReplaceAllString(*fontSpec.Family, "")
+	}
+	if fontSpec.Size != nil {
+		size = strconv.Itoa(*fontSpec.Size)
+	}
+	if fontSpec.Color != nil {
+		color = strings.ToLower(*fontSpec.Color)
+	}
+
+	return family + "/" + size + "/" + color
+}
+
+// Get the normalized text of a token. Texts with digits change between documents of the same
+// layout (dates, amounts, numbers) and are not used, like texts with less than three letters
+func tokenText(content string) (string, bool) {
+	letters := 0
+	for _, r := range content {
+		if unicode.IsDigit(r) {
+			return "", false
+		}
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	if letters < 3 {
+		return "", false
+	}
+
+	return strings.ToLower(strings.Join(strings.Fields(content), " ")), true
+}
+
+// Get the similarity of the layouts between 0 and 1. If the producer of a fingerprint is unknown,
+// the similarity is computed without the producer
+func (f Fingerprint) Similarity(other Fingerprint) float64 {
+	score, total := 0.0, 1.0
+	switch {
+	case f.Producer == "" || other.Producer == "":
+		total -= producerWeight
+	case f.Producer == other.Producer:
+		score += producerWeight
+	}
+	if abs(f.Width-other.Width) <= pageSizeTolerance && abs(f.Height-other.Height) <= pageSizeTolerance {
+		score += pageSizeWeight
+	}
+
+	score += fontsWeight * jaccard(f.Fonts, other.Fonts)
+	score += tokensWeight * tokenSimilarity(f.Tokens, other.Tokens)
+
+	return score / total
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// Share of the fonts used in both documents, 1 if both have none
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	set := map[string]bool{}
+	for _, value := range a {
+		set[value] = true
+	}
+
+	both := 0
+	union := len(set)
+	seen := map[string]bool{}
+	for _, value := range b {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+
+		if set[value] {
+			both++
+		} else {
+			union++
+		}
+	}
+
+	return float64(both) / float64(union)
+}
+
+// Share of the tokens found at the same position in the other document, 1 if both have none
+func tokenSimilarity(a, b []Token) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	used := make([]bool, len(b))
+	matched := 0
+	for _, token := range a {
+		for i, other := range b {
+			if used[i] || token.Text != other.Text ||
+				abs(token.Left-other.Left) > tokenTolerance || abs(token.Top-other.Top) > tokenTolerance {
+				continue
+			}
+
+			used[i] = true
+			matched++
+			break
+		}
+	}
+
+	return 2 * float64(matched) / float64(len(a)+len(b))
+}
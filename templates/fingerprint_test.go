@@ -0,0 +1,94 @@
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+	"github.com/nextunit-io/go-pdf2X/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+func fontSpec(id, size int, family string) pdf2html.PdfXmlFontSpec {
+	return pdf2html.PdfXmlFontSpec{
+		ID:     pointerHelperFn(id),
+		Size:   pointerHelperFn(size),
+		Family: pointerHelperFn(family),
+		Color:  pointerHelperFn("#000000"),
+	}
+}
+
+// pdfinfo information of the documents of a layout
+var layoutInfo = &pdfinfo.Info{Producer: pointerHelperFn("Microsoft: Print To PDF")}
+
+// Document of a layout, the labels are fixed and the values change between documents
+func layoutData(labels []string, subset string, number string, shift int) pdf2html.PdfXmlData {
+	texts := []pdf2html.PdfXmlText{}
+	for i, label := range labels {
+		texts = append(texts, text(50+i*30+shift, 50, 80, label), text(50+i*30+shift, 300, 60, number))
+	}
+
+	return pdf2html.PdfXmlData{
+		Producer: pointerHelperFn("poppler"),
+		Pages: []pdf2html.PdfXmlPage{
+			{
+				PageNumber: pointerHelperFn(1),
+				Width:      pointerHelperFn(892),
+				Height:     pointerHelperFn(1263),
+				FontSpecs:  []pdf2html.PdfXmlFontSpec{fontSpec(0, 12, subset+"+Calibri"), fontSpec(1, 16, subset+"+Calibri-Bold")},
+				Texts:      texts,
+			},
+			{PageNumber: pointerHelperFn(2), FontSpecs: []pdf2html.PdfXmlFontSpec{fontSpec(2, 8, subset+"+Arial")}},
+		},
+	}
+}
+
+func TestNewFingerprint(t *testing.T) {
+	data := layoutData([]string{"Invoice  No.", "Due Date", "Total"}, "ABCDEF", "12.00", 0)
+	data.Pages[0].Texts = append(data.Pages[0].Texts, text(10, 10, 10, "ab"), pdf2html.PdfXmlText{Text: pointerHelperFn("Unplaced")})
+
+	// The producer is the one of pdfinfo, not poppler of pdftohtml
+	assert.Equal(t, templates.Fingerprint{
+		Producer: "Microsoft: Print To PDF",
+		Width:    892,
+		Height:   1263,
+		Fonts:    []string{"Arial/8/#000000", "Calibri-Bold/16/#000000", "Calibri/12/#000000"},
+		Tokens: []templates.Token{
+			{Text: "invoice no.", Left: 50, Top: 50},
+			{Text: "due date", Left: 50, Top: 80},
+			{Text: "total", Left: 50, Top: 110},
+		},
+	}, templates.NewFingerprint(data, layoutInfo))
+
+	assert.Equal(t, templates.Fingerprint{Fonts: []string{}, Tokens: []templates.Token{}}, templates.NewFingerprint(pdf2html.PdfXmlData{}, nil))
+}
+
+func TestFingerprintSimilarity(t *testing.T) {
+	labels := []string{"Invoice No.", "Due Date", "Total", "Customer"}
+	fingerprint := templates.NewFingerprint(layoutData(labels, "ABCDEF", "12.00", 0), layoutInfo)
+
+	// Another document of the same layout with other subsets, values and a small shift
+	same := templates.NewFingerprint(layoutData(labels, "GHIJKL", "99.50", 5), layoutInfo)
+	assert.InDelta(t, 1.0, fingerprint.Similarity(same), 0.0001)
+
+	// Same fonts and page size, but other labels
+	other := templates.NewFingerprint(layoutData([]string{"Statement", "Balance", "Account"}, "ABCDEF", "1", 0), layoutInfo)
+	assert.InDelta(t, 0.6, fingerprint.Similarity(other), 0.0001)
+
+	// Half of the labels moved too far
+	moved := layoutData(labels, "ABCDEF", "12.00", 0)
+	*moved.Pages[0].Texts[0].Top += 50
+	*moved.Pages[0].Texts[2].Top += 50
+	assert.InDelta(t, 0.8, fingerprint.Similarity(templates.NewFingerprint(moved, layoutInfo)), 0.0001)
+
+	// Another producer
+	producer := templates.NewFingerprint(layoutData(labels, "GHIJKL", "99.50", 5), &pdfinfo.Info{Producer: pointerHelperFn("SAP")})
+	assert.InDelta(t, 0.9, fingerprint.Similarity(producer), 0.0001)
+
+	// Without pdfinfo the producer is unknown and not compared
+	unknown := templates.NewFingerprint(layoutData(labels, "GHIJKL", "99.50", 5), nil)
+	assert.InDelta(t, 1.0, fingerprint.Similarity(unknown), 0.0001)
+	assert.InDelta(t, 0.5/0.9, fingerprint.Similarity(templates.NewFingerprint(layoutData([]string{"Statement"}, "ABCDEF", "1", 0), nil)), 0.0001)
+
+	assert.InDelta(t, 1.0, templates.Fingerprint{}.Similarity(templates.Fingerprint{}), 0.0001)
+}
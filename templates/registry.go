@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"github.com/nextunit-io/go-pdf2X/pdfinfo"
+)
+
+// Template with its match score for a document
+type Match struct {
+	Template *Template
+	Score    float64 // similarity of the fingerprints between 0 and 1
+}
+
+// Templates with the fingerprints of their layouts to pick the template of a document
+type Registry struct {
+	MinScore float64 // minimum score of a match (default 0.5)
+
+	templates    []*Template
+	fingerprints []Fingerprint
+}
+
+func NewRegistry() *Registry {
+	return &Registry{MinScore: 0.5}
+}
+
+// Adds a template with the fingerprint of its layout, e.g. computed with NewFingerprint of a sample
+func (r *Registry) Add(template *Template, fingerprint Fingerprint) {
+	r.templates = append(r.templates, template)
+	r.fingerprints = append(r.fingerprints, fingerprint)
+}
+
+// Adds a template with the fingerprint of the template file
+func (r *Registry) AddTemplate(template *Template) error {
+	if template.Fingerprint == nil {
+		return fmt.Errorf("template %s has no fingerprint", template.Name)
+	}
+
+	r.Add(template, *template.Fingerprint)
+	return nil
+}
+
+// Get all templates with their scores for the document, best match first. The pdfinfo information
+// can be nil, see NewFingerprint
+func (r *Registry) Scores(data pdf2html.PdfXmlData, info *pdfinfo.Info) []Match {
+	fingerprint := NewFingerprint(data, info)
+
+	matches := []Match{}
+	for i, template := range r.templates {
+		matches = append(matches, Match{Template: template, Score: r.fingerprints[i].Similarity(fingerprint)})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches
+}
+
+// Get the template that matches the document best. It is an error if no template reaches the MinScore
+func (r *Registry) Match(data pdf2html.PdfXmlData, info *pdfinfo.Info) (*Match, error) {
+	matches := r.Scores(data, info)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no templates registered")
+	}
+
+	if matches[0].Score < r.MinScore {
+		return &matches[0], fmt.Errorf("no template matches, best is %s with %.2f", matches[0].Template.Name, matches[0].Score)
+	}
+
+	return &matches[0], nil
+}
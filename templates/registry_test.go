@@ -0,0 +1,65 @@
+package templates_test
+
+import (
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/templates"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRegistry(t *testing.T) {
+	invoiceLabels := []string{"Invoice No.", "Due Date", "Total"}
+	statementLabels := []string{"Statement", "Opening balance", "Closing balance"}
+
+	invoice := &templates.Template{Name: "invoice"}
+	statement := &templates.Template{Name: "statement"}
+
+	registry := templates.NewRegistry()
+	registry.Add(invoice, templates.NewFingerprint(layoutData(invoiceLabels, "ABCDEF", "1", 0), layoutInfo))
+	registry.Add(statement, templates.NewFingerprint(layoutData(statementLabels, "ABCDEF", "1", 0), layoutInfo))
+
+	t.Run("best match", func(t *testing.T) {
+		match, err := registry.Match(layoutData(statementLabels, "QWERTZ", "250.00", 3), layoutInfo)
+		assert.Nil(t, err)
+		assert.Equal(t, statement, match.Template)
+		assert.InDelta(t, 1.0, match.Score, 0.0001)
+
+		scores := registry.Scores(layoutData(invoiceLabels, "QWERTZ", "7", 0), nil)
+		assert.Len(t, scores, 2)
+		assert.Equal(t, invoice, scores[0].Template)
+		assert.Equal(t, statement, scores[1].Template)
+		assert.Greater(t, scores[0].Score, scores[1].Score)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		registry.MinScore = 0.9
+		defer func() { registry.MinScore = 0.5 }()
+
+		match, err := registry.Match(layoutData([]string{"Delivery note"}, "ABCDEF", "1", 0), layoutInfo)
+		assert.EqualError(t, err, "no template matches, best is invoice with 0.60")
+		assert.Equal(t, invoice, match.Template)
+
+		_, err = templates.NewRegistry().Match(layoutData(invoiceLabels, "ABCDEF", "1", 0), layoutInfo)
+		assert.EqualError(t, err, "no templates registered")
+	})
+
+	t.Run("fingerprint of the template file", func(t *testing.T) {
+		content, err := yaml.Marshal(map[string]any{
+			"name":        "invoice",
+			"fingerprint": templates.NewFingerprint(layoutData(invoiceLabels, "ABCDEF", "1", 0), layoutInfo),
+		})
+		assert.Nil(t, err)
+
+		template, err := templates.Parse(content)
+		assert.Nil(t, err)
+
+		registry := templates.NewRegistry()
+		assert.Nil(t, registry.AddTemplate(template))
+		assert.EqualError(t, registry.AddTemplate(&templates.Template{Name: "empty"}), "template empty has no fingerprint")
+
+		match, err := registry.Match(layoutData(invoiceLabels, "XYZXYZ", "2", 0), layoutInfo)
+		assert.Nil(t, err)
+		assert.Equal(t, "invoice", match.Template.Name)
+	})
+}
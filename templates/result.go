@@ -0,0 +1,85 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+)
+
+// Result of applying a template to a document
+type Result struct {
+	Template string                      // name of the template
+	Fields   map[string]FieldResult      // fields by name
+	Tables   map[string][]map[string]any // rows of the tables by name, the values of a row by column name
+}
+
+type FieldResult struct {
+	Value      any           // converted value, nil if the field is not found
+	Text       string        // text of the value before the conversion
+	Found      bool          // the value is found
+	Confidence float64       // confidence of the value between 0 and 1
+	Page       int           // page of the value
+	Rect       pdf2html.Rect // box of the texts of the value
+}
+
+// Error of a field or of a column of a table row
+type Error struct {
+	Name string // name of the field or the table
+	Row  int    // row of the table, -1 for fields
+	Err  error
+}
+
+func (e Error) Error() string {
+	if e.Row < 0 {
+		return fmt.Sprintf("field %s: %s", e.Name, e.Err)
+	}
+
+	return fmt.Sprintf("table %s, row %d: %s", e.Name, e.Row, e.Err)
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// All errors of applying a template
+type Errors []Error
+
+func (e Errors) Error() string {
+	messages := []string{}
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Get the values of the fields and the rows of the tables by name. Fields that are not found are nil
+func (r Result) Values() map[string]any {
+	values := map[string]any{}
+	for name, field := range r.Fields {
+		values[name] = field.Value
+	}
+	for name, rows := range r.Tables {
+		values[name] = rows
+	}
+
+	return values
+}
+
+// Decodes the values into a struct. The names are matched like encoding/json does, so
+// struct fields can be named with json tags, dates are decoded into time.Time fields
+func (r Result) Decode(out any) error {
+	content, err := json.Marshal(r.Values())
+	if err != nil {
+		return fmt.Errorf("cannot decode result: %w", err)
+	}
+
+	err = json.Unmarshal(content, out)
+	if err != nil {
+		return fmt.Errorf("cannot decode result: %w", err)
+	}
+
+	return nil
+}
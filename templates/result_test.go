@@ -0,0 +1,51 @@
+package templates_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nextunit-io/go-pdf2X/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultDecode(t *testing.T) {
+	template, err := templates.Parse([]byte(invoiceTemplate))
+	assert.Nil(t, err)
+
+	result, _ := template.Apply(invoiceData())
+
+	values := result.Values()
+	assert.Equal(t, "AB-1234", values["invoiceNumber"])
+	assert.Nil(t, values["reference"])
+	assert.Len(t, values["items"], 3)
+
+	type item struct {
+		Description string  `json:"description"`
+		Quantity    int     `json:"quantity"`
+		Amount      float64 `json:"amount"`
+	}
+	type invoice struct {
+		InvoiceNumber string    `json:"invoiceNumber"`
+		Total         float64   `json:"total"`
+		Date          time.Time `json:"date"`
+		Reference     *string   `json:"reference"`
+		Items         []item    `json:"items"`
+	}
+
+	decoded := invoice{}
+	assert.Nil(t, result.Decode(&decoded))
+	assert.Equal(t, invoice{
+		InvoiceNumber: "AB-1234",
+		Total:         1251,
+		Date:          time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+		Items: []item{
+			{Description: "Widget with extra", Quantity: 2, Amount: 1234.5},
+			{Description: "Gadget", Quantity: 1, Amount: 10},
+			{Description: "Support", Quantity: 3, Amount: 7.5},
+		},
+	}, decoded)
+
+	assert.ErrorContains(t, result.Decode(&struct {
+		Total string `json:"total"`
+	}{}), "cannot decode result")
+}
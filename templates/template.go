@@ -0,0 +1,306 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/nextunit-io/go-pdf2X/pdf2html"
+	"gopkg.in/yaml.v3"
+)
+
+// Extraction template of a document layout
+type Template struct {
+	Name   string          `yaml:"name"`   // name of the layout
+	Number *NumberFormat   `yaml:"number"` // default format of numbers of all fields and columns
+	Fields []FieldTemplate `yaml:"fields"` // single values found next to a label
+	Tables []TableTemplate `yaml:"tables"` // tables of the document
+
+	Fingerprint *Fingerprint `yaml:"fingerprint"` // layout of the documents of the template, used by the Registry
+}
+
+// Separators of numbers, e.g. decimal "," and thousands "." for German documents
+type NumberFormat struct {
+	Decimal   string `yaml:"decimal"`   // decimal separator (default ".")
+	Thousands string `yaml:"thousands"` // thousands separator (default ",")
+}
+
+// Text that marks a position in the document. All set conditions have to match
+type Anchor struct {
+	Text      string `yaml:"text"`      // text that should be contained in the anchor text
+	Pattern   string `yaml:"pattern"`   // regular expression that should match the trimmed anchor text
+	FontSize  *int   `yaml:"fontSize"`  // size of the font of the anchor text
+	Offset    int    `yaml:"offset"`    // added to the top position of a table anchor
+	Exclusive bool   `yaml:"exclusive"` // a table starts below the line of the start anchor
+
+	pattern *regexp.Regexp
+}
+
+// Area relative to the top left corner of the label
+type Area struct {
+	Left   int `yaml:"left"`
+	Top    int `yaml:"top"`
+	Width  int `yaml:"width"`
+	Height int `yaml:"height"`
+}
+
+// Converts the text of a value
+type Conversion struct {
+	Type     string        `yaml:"type"`     // string (default), int, decimal or date
+	Layout   string        `yaml:"layout"`   // layout of dates for time.Parse, e.g. 02.01.2006
+	Number   *NumberFormat `yaml:"number"`   // format of numbers, overrides the one of the template
+	Pattern  string        `yaml:"pattern"`  // regular expression the value has to match, the first group is the value if there is one
+	Required bool          `yaml:"required"` // a missing value is an error
+
+	pattern *regexp.Regexp
+}
+
+// Single value of the document found relative to a label
+type FieldTemplate struct {
+	Conversion `yaml:",inline"`
+
+	Name        string `yaml:"name"`        // name of the field in the result
+	Label       Anchor `yaml:"label"`       // label the value is found relative to
+	Page        int    `yaml:"page"`        // page of the label (default all pages)
+	Direction   string `yaml:"direction"`   // right (default), below or area
+	Area        *Area  `yaml:"area"`        // area relative to the label with direction area
+	MaxDistance int    `yaml:"maxDistance"` // maximum distance of the value from the label (default unlimited)
+}
+
+// Table of the document, extracted with pdf2html.PdfXmlData.ExtractTable
+type TableTemplate struct {
+	Name           string           `yaml:"name"`           // name of the table in the result
+	FirstPage      int              `yaml:"firstPage"`      // first page of the table (default first page)
+	LastPage       int              `yaml:"lastPage"`       // last page of the table (default last page)
+	From           int              `yaml:"from"`           // top position the table starts at without a start anchor
+	To             *int             `yaml:"to"`             // top position the table ends at without an end anchor
+	Start          *Anchor          `yaml:"start"`          // anchor of the start of the table on every page
+	End            *Anchor          `yaml:"end"`            // anchor of the end of the table on every page
+	HeightVariance int              `yaml:"heightVariance"` // variance of the top positions of texts in the same row (default 5)
+	Columns        []ColumnTemplate `yaml:"columns"`        // columns from left to right
+	Filter         *RowFilter       `yaml:"filter"`         // rows that are skipped
+	Merge          *MergeTemplate   `yaml:"merge"`          // merges continuation lines into the previous row
+}
+
+type ColumnTemplate struct {
+	Conversion `yaml:",inline"`
+
+	Name      string `yaml:"name"`      // name of the column in the rows of the result
+	From      int    `yaml:"from"`      // start of the range of the aligned edge of the texts in the column
+	To        int    `yaml:"to"`        // end of the range of the aligned edge of the texts in the column
+	Alignment string `yaml:"alignment"` // left (default), right or center
+}
+
+type RowFilter struct {
+	Required []string `yaml:"required"` // columns that need a value
+	Exclude  string   `yaml:"exclude"`  // regular expression, rows with a matching cell are skipped
+
+	exclude *regexp.Regexp
+}
+
+type MergeTemplate struct {
+	KeyColumns []string `yaml:"keyColumns"` // a row continues the previous one if these columns are empty
+	MaxGap     int      `yaml:"maxGap"`     // maximum vertical gap to the previous row
+}
+
+var (
+	fieldTypes = map[string]bool{"": true, "string": true, "int": true, "decimal": true, "date": true}
+	directions = map[string]bool{"": true, "right": true, "below": true, "area": true}
+	alignments = map[string]pdf2html.ColumnAlignment{"": pdf2html.AlignLeft, "left": pdf2html.AlignLeft, "right": pdf2html.AlignRight, "center": pdf2html.AlignCenter}
+)
+
+// Parses a template in YAML or JSON
+func Parse(content []byte) (*Template, error) {
+	template := &Template{}
+	err := yaml.Unmarshal(content, template)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse template: %w", err)
+	}
+
+	err = template.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// Loads a template file in YAML or JSON
+func Load(path string) (*Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read template %s: %w", path, err)
+	}
+
+	template, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return template, nil
+}
+
+// Validates the template and compiles its regular expressions
+func (t *Template) compile() error {
+	names := map[string]bool{}
+	for i := range t.Fields {
+		field := &t.Fields[i]
+		if field.Name == "" {
+			return fmt.Errorf("field %d has no name", i)
+		}
+		if names[field.Name] {
+			return fmt.Errorf("duplicate name %s", field.Name)
+		}
+		names[field.Name] = true
+
+		if !directions[field.Direction] {
+			return fmt.Errorf("field %s: unknown direction %q", field.Name, field.Direction)
+		}
+		if field.Direction == "area" && field.Area == nil {
+			return fmt.Errorf("field %s: missing area", field.Name)
+		}
+
+		err := field.Label.compile()
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if field.Label.Text == "" && field.Label.pattern == nil && field.Label.FontSize == nil {
+			return fmt.Errorf("field %s: missing label", field.Name)
+		}
+
+		err = field.Conversion.compile()
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+
+	for i := range t.Tables {
+		table := &t.Tables[i]
+		if table.Name == "" {
+			return fmt.Errorf("table %d has no name", i)
+		}
+		if names[table.Name] {
+			return fmt.Errorf("duplicate name %s", table.Name)
+		}
+		names[table.Name] = true
+
+		err := table.compile()
+		if err != nil {
+			return fmt.Errorf("table %s: %w", table.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Anchor) compile() error {
+	if a.Pattern == "" {
+		return nil
+	}
+
+	pattern, err := regexp.Compile(a.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid anchor pattern: %w", err)
+	}
+	a.pattern = pattern
+
+	return nil
+}
+
+func (c *Conversion) compile() error {
+	if !fieldTypes[c.Type] {
+		return fmt.Errorf("unknown type %q", c.Type)
+	}
+	if c.Type == "date" && c.Layout == "" {
+		return fmt.Errorf("missing layout of date")
+	}
+
+	if c.Pattern != "" {
+		pattern, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		c.pattern = pattern
+	}
+
+	return nil
+}
+
+func (t *TableTemplate) compile() error {
+	if len(t.Columns) == 0 {
+		return fmt.Errorf("missing columns")
+	}
+
+	columns := map[string]bool{}
+	for i := range t.Columns {
+		column := &t.Columns[i]
+		if column.Name == "" {
+			return fmt.Errorf("column %d has no name", i)
+		}
+		if columns[column.Name] {
+			return fmt.Errorf("duplicate column %s", column.Name)
+		}
+		columns[column.Name] = true
+
+		if _, ok := alignments[column.Alignment]; !ok {
+			return fmt.Errorf("column %s: unknown alignment %q", column.Name, column.Alignment)
+		}
+
+		err := column.Conversion.compile()
+		if err != nil {
+			return fmt.Errorf("column %s: %w", column.Name, err)
+		}
+	}
+
+	for _, anchor := range []*Anchor{t.Start, t.End} {
+		if anchor == nil {
+			continue
+		}
+
+		err := anchor.compile()
+		if err != nil {
+			return err
+		}
+	}
+
+	if t.Filter != nil {
+		for _, name := range t.Filter.Required {
+			if !columns[name] {
+				return fmt.Errorf("unknown column %s in filter", name)
+			}
+		}
+
+		if t.Filter.Exclude != "" {
+			exclude, err := regexp.Compile(t.Filter.Exclude)
+			if err != nil {
+				return fmt.Errorf("invalid exclude pattern: %w", err)
+			}
+			t.Filter.exclude = exclude
+		}
+	}
+
+	if t.Merge != nil {
+		for _, name := range t.Merge.KeyColumns {
+			if !columns[name] {
+				return fmt.Errorf("unknown column %s in merge", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Get the anchor for the pdf2html tables and searches
+func (a Anchor) tableAnchor() pdf2html.PdfXmlTableAnchor {
+	anchor := pdf2html.PdfXmlTableAnchor{
+		Text:      a.Text,
+		Pattern:   a.pattern,
+		Offset:    a.Offset,
+		Exclusive: a.Exclusive,
+	}
+	if a.FontSize != nil {
+		anchor.FontSpec = &pdf2html.PdfXmlFontSpec{Size: a.FontSize}
+	}
+
+	return anchor
+}
@@ -0,0 +1,134 @@
+package templates_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nextunit-io/go-pdf2X/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+func pointerHelperFn[T any](x T) *T {
+	return &x
+}
+
+const invoiceTemplate = `
+name: acme-invoice
+number:
+  decimal: ","
+  thousands: "."
+fields:
+  - name: invoiceNumber
+    label:
+      text: "Invoice no."
+    pattern: "^[A-Z]{2}-[0-9]+$"
+    required: true
+  - name: total
+    label:
+      pattern: "^Invoice total"
+    type: decimal
+    required: true
+  - name: date
+    label:
+      text: "Date"
+    direction: below
+    maxDistance: 20
+    type: date
+    layout: "02.01.2006"
+  - name: address
+    label:
+      text: "Bill to"
+    direction: area
+    area: {left: 0, top: 15, width: 200, height: 40}
+  - name: reference
+    label:
+      text: "Reference"
+tables:
+  - name: items
+    start:
+      text: "Description"
+      exclusive: true
+    end:
+      pattern: "^(Invoice total|Carried forward)"
+    columns:
+      - name: description
+        from: 45
+        to: 55
+      - name: quantity
+        from: 300
+        to: 310
+        type: int
+      - name: amount
+        from: 480
+        to: 500
+        alignment: right
+        type: decimal
+    filter:
+      required: [amount]
+    merge:
+      keyColumns: [amount]
+`
+
+func TestParse(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		template, err := templates.Parse([]byte(invoiceTemplate))
+		assert.Nil(t, err)
+		assert.Equal(t, "acme-invoice", template.Name)
+		assert.Len(t, template.Fields, 5)
+		assert.Equal(t, "below", template.Fields[2].Direction)
+		assert.Equal(t, "02.01.2006", template.Fields[2].Layout)
+		assert.Equal(t, &templates.Area{Left: 0, Top: 15, Width: 200, Height: 40}, template.Fields[3].Area)
+		assert.Len(t, template.Tables[0].Columns, 3)
+		assert.Equal(t, "right", template.Tables[0].Columns[2].Alignment)
+		assert.Equal(t, []string{"amount"}, template.Tables[0].Merge.KeyColumns)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		template, err := templates.Parse([]byte(`{
+			"name": "json",
+			"fields": [{"name": "total", "label": {"text": "Total"}, "type": "decimal", "number": {"decimal": ","}}]
+		}`))
+		assert.Nil(t, err)
+		assert.Equal(t, "decimal", template.Fields[0].Type)
+		assert.Equal(t, ",", template.Fields[0].Number.Decimal)
+	})
+
+	t.Run("invalid templates", func(t *testing.T) {
+		for _, test := range []struct {
+			content  string
+			expected string
+		}{
+			{"fields: [{label: {text: a}}]", "field 0 has no name"},
+			{"fields: [{name: a, label: {text: a}}, {name: a, label: {text: b}}]", "duplicate name a"},
+			{"fields: [{name: a}]", "field a: missing label"},
+			{"fields: [{name: a, label: {text: a}, direction: left}]", `field a: unknown direction "left"`},
+			{"fields: [{name: a, label: {text: a}, direction: area}]", "field a: missing area"},
+			{"fields: [{name: a, label: {text: a}, type: money}]", `field a: unknown type "money"`},
+			{"fields: [{name: a, label: {text: a}, type: date}]", "field a: missing layout of date"},
+			{"fields: [{name: a, label: {pattern: '('}}]", "field a: invalid anchor pattern: error parsing regexp: missing closing ): `(`"},
+			{"tables: [{name: t}]", "table t: missing columns"},
+			{"tables: [{name: t, columns: [{name: c, alignment: top}]}]", `table t: column c: unknown alignment "top"`},
+			{"tables: [{name: t, columns: [{name: c}], filter: {required: [d]}}]", "table t: unknown column d in filter"},
+			{"tables: [{name: t, columns: [{name: c}], merge: {keyColumns: [d]}}]", "table t: unknown column d in merge"},
+		} {
+			_, err := templates.Parse([]byte(test.content))
+			assert.EqualError(t, err, test.expected, test.content)
+		}
+
+		_, err := templates.Parse([]byte("fields: {"))
+		assert.ErrorContains(t, err, "cannot parse template")
+	})
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invoice.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(invoiceTemplate), 0o644))
+
+	template, err := templates.Load(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "acme-invoice", template.Name)
+
+	_, err = templates.Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "cannot read template")
+}